@@ -0,0 +1,72 @@
+// Command headless steps the game simulation without a window or GPU, which
+// makes it usable in CI and for soak testing.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/systems"
+)
+
+func main() {
+	frames := flag.Int("frames", 10000, "number of fixed steps to simulate")
+	seed := flag.Int64("seed", 1, "random seed")
+	autopilot := flag.Bool("autopilot", true, "spin and fire instead of sitting idle")
+	ruleset := flag.String("ruleset", "classic", "game mode: classic or arcade")
+	flight := flag.String("flight", "", "ship flight model, classic or modern, overriding the ruleset's")
+	flag.Parse()
+
+	world := game.NewWorld(*seed)
+	config := game.ConfigOf(world)
+	if !config.UseRuleset(*ruleset) {
+		fmt.Fprintf(os.Stderr, "unknown ruleset %q\n", *ruleset)
+		os.Exit(2)
+	}
+	if *flight != "" {
+		if _, ok := game.FlightPresets[*flight]; !ok {
+			fmt.Fprintf(os.Stderr, "unknown flight model %q\n", *flight)
+			os.Exit(2)
+		}
+		config.Flight = *flight
+	}
+
+	var source systems.InputSource = systems.IdleInput{}
+	if *autopilot {
+		// Turn constantly and fire every half second
+		frame := 0
+		source = systems.InputFunc(func() systems.InputState {
+			frame++
+			return systems.InputState{Right: true, Fire: frame%30 == 0}
+		})
+	}
+	systems.AddGameSystems(world, source)
+
+	destroyed := 0
+	gameOver := false
+	ecs.Subscribe(world, func(e events.AsteroidDestroyed) { destroyed++ })
+	ecs.Subscribe(world, func(e events.GameOver) { gameOver = true })
+
+	ship := game.StartGame(world)
+
+	start := time.Now()
+	steps := 0
+	for steps < *frames && !gameOver {
+		world.Step()
+		steps++
+	}
+	elapsed := time.Since(start)
+
+	player, _ := ecs.Get[components.Player](world, ship)
+	fmt.Printf("Simulated %d frames (%.1fs of game time) in %v\n", steps, world.Clock().Now(), elapsed)
+	fmt.Printf("Score: %d, lives: %d, asteroids destroyed: %d\n", player.Score, player.Lives, destroyed)
+	if gameOver {
+		fmt.Printf("Game over after %d frames\n", steps)
+	}
+}
@@ -1,8 +1,10 @@
 package components
 
 import (
-	"time"
 	"image/color"
+
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/geom"
 )
 
 type Position struct {
@@ -10,8 +12,8 @@ type Position struct {
 }
 
 type Velocity struct {
-	DX, DY    float64
-	MaxSpeed  float64
+	DX, DY   float64
+	MaxSpeed float64
 }
 
 type Rotation struct {
@@ -26,17 +28,41 @@ const (
 	RenderableTypeBullet
 	RenderableTypeAsteroid
 	RenderableTypeExplosion
+	RenderableTypeSaucer
+	RenderableTypePowerUp
 )
 
+var renderableTypeNames = []string{"ship", "bullet", "asteroid", "explosion", "saucer", "powerup"}
+
+// MarshalText lets prefab and snapshot files name renderable types.
+func (t RenderableType) MarshalText() ([]byte, error) {
+	return marshalEnum(int(t), renderableTypeNames)
+}
+
+func (t *RenderableType) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum(text, renderableTypeNames)
+	*t = RenderableType(v)
+	return err
+}
+
 type Renderable struct {
 	Type    RenderableType
 	Scale   float64
 	Visible bool
 }
 
+// Lifetime expires an entity Duration seconds after Created, both measured
+// in simulation time.
 type Lifetime struct {
-	Created  time.Time
-	Duration time.Duration
+	Created  float64
+	Duration float64
+}
+
+// PreviousTransform holds where an entity was at the end of the previous
+// simulation step so rendering can interpolate between steps.
+type PreviousTransform struct {
+	X, Y  float64
+	Angle float64
 }
 
 type Player struct {
@@ -44,39 +70,88 @@ type Player struct {
 	Score       int
 	Lives       int
 	IsGameOver  bool
+	Wave        int // Wave the player has reached
 }
 
-type ColliderType int
-
-const (
-	ColliderTypeShip ColliderType = iota
-	ColliderTypeBullet
-	ColliderTypeAsteroid
-)
-
+// ScreenWrap marks entities that re-enter from the opposite edge when they
+// leave the screen. Anything else is destroyed once off screen.
+type ScreenWrap struct{}
+
+// Collider is a circle of Radius unless Polygon is set, in which case the
+// polygon is the exact shape and Radius encloses it. Renderers draw the same
+// polygon, so what is seen is what is hit.
+//
+// Layer is the single layer the collider is on and Mask the layers it
+// collides with; two colliders only interact when each one's mask includes
+// the other's layer.
 type Collider struct {
-	Radius float64
-	Type   ColliderType
+	Radius  float64
+	Layer   CollisionLayer
+	Mask    CollisionLayer
+	Polygon geom.Polygon `json:",omitempty"`
 }
 
 type Input struct {
-	Rotate  float64 // -1 for left, 1 for right
-	Forward bool
-	Shoot   bool
-	MouseX  int
-	MouseY  int
+	Rotate       float64 // -1 turns fully left, 1 fully right
+	Heading      float64 // Angle to turn to when Steer is set and Rotate isn't
+	Steer        bool
+	Forward      bool
+	Reverse      bool
+	Shoot        bool
+	ShootHeld    bool // Fire is held down, for weapons that repeat
+	Hyperspace   bool
+	MouseX       int
+	MouseY       int
 	MousePressed bool
 }
 
 type Asteroid struct {
-	Size int // 0 = small, 1 = medium, 2 = large
+	Size      int      // 0 = small, 1 = medium, 2 = large
+	SplitInto []string // Prefab of each fragment it breaks into, empty if it doesn't split
+	Spread    float64  // Angle in radians the fragments fan out over
+	Blast     float64  // How hard the impact's momentum throws the fragments
+}
+
+// Saucer is a hostile flying saucer. It crosses the screen once, changing
+// course every TurnInterval seconds and firing every FireInterval.
+type Saucer struct {
+	Points       int     // Awarded for shooting it down
+	Accuracy     float64 // 0 fires in any direction, 1 leads the ship exactly
+	FireInterval float64
+	TurnInterval float64
+	NextShot     float64 // Simulation time of the next shot
+	NextTurn     float64 // Simulation time of the next course change
+	Travelled    float64 // Distance flown across the screen; it leaves after one width
 }
 
 type Explosion struct {
-	Age     float64 // Time since explosion started
-	MaxAge  float64 // When to remove the explosion
-	Radius  float64 // Current radius of explosion
-	Pieces  int     // Number of particles
+	Age    float64 // Time since explosion started
+	MaxAge float64 // When to remove the explosion
+	Radius float64 // Current radius of explosion
+	Pieces int     // Number of particles
+}
+
+// FlightModel is how a ship handles. Accelerations are per second squared,
+// and Drag and TurnDamping are the fraction of speed and of spin lost each
+// second, applied continuously so the handling doesn't depend on the step.
+type FlightModel struct {
+	Thrust           float64
+	ReverseThrust    float64 // 0 for no reverse thrust
+	Drag             float64
+	TurnAcceleration float64 // Radians per second squared at full turn
+	MaxTurnRate      float64 // Radians per second
+	TurnDamping      float64 // Slows the spin while not turning
+}
+
+// Hyperspace lets a ship jump out of danger. It vanishes for Delay seconds
+// and reappears somewhere random, with a Risk chance of blowing up as it
+// does.
+type Hyperspace struct {
+	Delay    float64
+	Cooldown float64 // Seconds after re-entry before it can jump again
+	Risk     float64
+	ReturnAt float64 // Simulation time it reappears, 0 when not in hyperspace
+	ReadyAt  float64 // Simulation time the next jump is allowed
 }
 
 type Invulnerable struct {
@@ -84,12 +159,42 @@ type Invulnerable struct {
 	Timer    float64 // Current time left
 }
 
+// RigidBody gives an entity mass for collision response. Mass and Inertia
+// are worked out from the collider outline and Density when it is created.
+type RigidBody struct {
+	Density        float64
+	Mass           float64
+	Inertia        float64 // Resistance to spinning about its position
+	Restitution    float64 // Bounciness, from 0 to 1
+	Friction       float64 // Grip at the contact, which turns sliding into spin
+	FractureEnergy float64 // Impact energy that breaks it apart, 0 for never
+}
+
 type Bullet struct {
-	ShooterID int
+	ShooterID ecs.EntityID
+	Piercing  bool // Carries on through whatever it hits
 }
 
+// Weapon fires Prefab projectiles from an entity's position along its
+// rotation. Each shot fires one projectile per Spread angle, measured from
+// the way it faces, or a single one straight ahead when Spread is empty.
+type Weapon struct {
+	Prefab          string
+	Interval        float64 // Fewest seconds between shots
+	MaxLive         int     // Most of its projectiles alive at once, 0 for no limit
+	Spread          []float64
+	AutoFire        bool    // Keeps firing while the trigger is held
+	InheritVelocity bool    // Projectiles carry the shooter's velocity as well as their own
+	NextShot        float64 // Simulation time it can fire again
+}
+
+// FastMover marks entities that can cross a collider in a single step, such
+// as bullets. Collision sweeps them along their path through the step
+// instead of only testing where they end up.
+type FastMover struct{}
+
 // UI button component
 type UIButton struct {
 	X, Y, Width, Height int
-	Color              color.Color
+	Color               color.Color
 }
@@ -0,0 +1,19 @@
+package components
+
+import "fmt"
+
+func marshalEnum(v int, names []string) ([]byte, error) {
+	if v < 0 || v >= len(names) {
+		return nil, fmt.Errorf("components: value %d out of range", v)
+	}
+	return []byte(names[v]), nil
+}
+
+func unmarshalEnum(text []byte, names []string) (int, error) {
+	for i, name := range names {
+		if string(text) == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("components: unknown name %q", text)
+}
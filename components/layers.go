@@ -0,0 +1,66 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CollisionLayer is a set of collision layers, one bit per layer.
+type CollisionLayer uint32
+
+var layerNames []string
+
+// NewCollisionLayer allocates a layer for a new kind of collider. Names
+// identify the layer in prefab and snapshot files.
+func NewCollisionLayer(name string) CollisionLayer {
+	if len(layerNames) == 32 {
+		panic("components: too many collision layers")
+	}
+	layerNames = append(layerNames, name)
+	return CollisionLayer(1) << (len(layerNames) - 1)
+}
+
+var (
+	LayerShip        = NewCollisionLayer("ship")
+	LayerBullet      = NewCollisionLayer("bullet")
+	LayerAsteroid    = NewCollisionLayer("asteroid")
+	LayerSaucer      = NewCollisionLayer("saucer")
+	LayerEnemyBullet = NewCollisionLayer("enemy_bullet")
+	LayerPowerUp     = NewCollisionLayer("powerup")
+)
+
+// Has reports whether every layer in other is in l.
+func (l CollisionLayer) Has(other CollisionLayer) bool {
+	return other != 0 && l&other == other
+}
+
+// MarshalText writes the layers as names separated by "|", e.g.
+// "ship|bullet".
+func (l CollisionLayer) MarshalText() ([]byte, error) {
+	var names []string
+	for i, name := range layerNames {
+		if l&(1<<i) != 0 {
+			names = append(names, name)
+			l &^= 1 << i
+		}
+	}
+	if l != 0 {
+		return nil, fmt.Errorf("components: unknown collision layers %#x", uint32(l))
+	}
+	return []byte(strings.Join(names, "|")), nil
+}
+
+func (l *CollisionLayer) UnmarshalText(text []byte) error {
+	*l = 0
+	if len(text) == 0 {
+		return nil
+	}
+	for _, name := range strings.Split(string(text), "|") {
+		i, err := unmarshalEnum([]byte(strings.TrimSpace(name)), layerNames)
+		if err != nil {
+			return err
+		}
+		*l |= 1 << i
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package components
+
+import "fmt"
+
+// PowerUpKind is what a power-up pickup does for the ship that collects it.
+type PowerUpKind int
+
+const (
+	PowerUpShield     PowerUpKind = iota // Asteroids, saucers and bullets can't hurt the ship
+	PowerUpTripleShot                    // Fires three bullets in a fan
+	PowerUpRapidFire                     // Keeps firing while fire is held
+	PowerUpPiercing                      // Bullets pass through what they hit
+	PowerUpExtraLife                     // An extra life, straight away
+)
+
+var powerUpKindNames = []string{"shield", "triple_shot", "rapid_fire", "piercing", "extra_life"}
+
+// MarshalText lets prefab, config and snapshot files name power-ups.
+func (k PowerUpKind) MarshalText() ([]byte, error) {
+	return marshalEnum(int(k), powerUpKindNames)
+}
+
+func (k *PowerUpKind) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum(text, powerUpKindNames)
+	*k = PowerUpKind(v)
+	return err
+}
+
+func (k PowerUpKind) String() string {
+	if k < 0 || int(k) >= len(powerUpKindNames) {
+		return fmt.Sprintf("PowerUpKind(%d)", int(k))
+	}
+	return powerUpKindNames[k]
+}
+
+// PowerUp is a pickup waiting to be collected.
+type PowerUp struct {
+	Kind PowerUpKind
+}
+
+// Effect is one timed power-up working on a ship.
+type Effect struct {
+	Kind      PowerUpKind
+	Remaining float64 // Seconds left
+}
+
+// Effects holds the timed power-ups a ship has collected.
+type Effects struct {
+	Active []Effect
+}
+
+// Has reports whether kind is currently working.
+func (e Effects) Has(kind PowerUpKind) bool {
+	for _, effect := range e.Active {
+		if effect.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Add starts kind for duration seconds, or tops it up if it is already
+// working.
+func (e *Effects) Add(kind PowerUpKind, duration float64) {
+	for i := range e.Active {
+		if e.Active[i].Kind == kind {
+			e.Active[i].Remaining = max(e.Active[i].Remaining, duration)
+			return
+		}
+	}
+	e.Active = append(e.Active, Effect{Kind: kind, Remaining: duration})
+}
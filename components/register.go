@@ -0,0 +1,31 @@
+package components
+
+import "github.com/bobbyhiddn/ecs-asteroids/ecs"
+
+// Register creates the world's store for every component type, so snapshots
+// and prefabs can refer to components by name before any system has
+// touched them.
+func Register(w *ecs.World) {
+	ecs.Register[Position](w)
+	ecs.Register[Velocity](w)
+	ecs.Register[Rotation](w)
+	ecs.Register[Renderable](w)
+	ecs.Register[Lifetime](w)
+	ecs.Register[PreviousTransform](w)
+	ecs.Register[Player](w)
+	ecs.Register[Collider](w)
+	ecs.Register[Input](w)
+	ecs.Register[Asteroid](w)
+	ecs.Register[Saucer](w)
+	ecs.Register[Explosion](w)
+	ecs.Register[Invulnerable](w)
+	ecs.Register[Hyperspace](w)
+	ecs.Register[FlightModel](w)
+	ecs.Register[PowerUp](w)
+	ecs.Register[Effects](w)
+	ecs.Register[Bullet](w)
+	ecs.Register[Weapon](w)
+	ecs.Register[FastMover](w)
+	ecs.Register[RigidBody](w)
+	ecs.Register[ScreenWrap](w)
+}
@@ -0,0 +1,70 @@
+package ecs
+
+// DefaultStep is the fixed simulation step used by NewWorld, in seconds.
+const DefaultStep = 1.0 / 60.0
+
+// Clock is the world's simulation clock. The simulation always advances in
+// whole Steps, independent of how often the host calls Advance, so behaviour
+// is the same at any display refresh rate and in headless runs.
+type Clock struct {
+	Step      float64 // Fixed simulation step in seconds
+	TimeScale float64 // Multiplier applied to real time, 1 = normal speed
+	MaxSteps  int     // Cap on steps per Advance so a long stall can't snowball
+
+	accumulator float64
+	elapsed     float64
+	frame       uint64
+}
+
+// NewClock returns a clock that steps every step seconds at normal speed.
+func NewClock(step float64) *Clock {
+	return &Clock{
+		Step:      step,
+		TimeScale: 1.0,
+		MaxSteps:  8,
+	}
+}
+
+// Now returns the simulated time in seconds at the start of the current
+// step. Timers in components should be measured against this rather than
+// the OS clock.
+func (c *Clock) Now() float64 {
+	return c.elapsed
+}
+
+// Frame returns the number of steps simulated so far.
+func (c *Clock) Frame() uint64 {
+	return c.frame
+}
+
+// Alpha returns how far real time has progressed between the last completed
+// step and the next one, from 0 to 1. Renderers use it to interpolate
+// between the previous and current positions of moving entities.
+func (c *Clock) Alpha() float64 {
+	if c.Step <= 0 {
+		return 0
+	}
+	return c.accumulator / c.Step
+}
+
+// accumulate adds real time and returns how many steps are now due.
+func (c *Clock) accumulate(realDt float64) int {
+	c.accumulator += realDt * c.TimeScale
+
+	steps := int(c.accumulator / c.Step)
+	if c.MaxSteps > 0 && steps > c.MaxSteps {
+		// Drop the backlog rather than trying to catch up all at once
+		steps = c.MaxSteps
+		c.accumulator = float64(steps) * c.Step
+	}
+	return steps
+}
+
+func (c *Clock) tick() {
+	c.accumulator -= c.Step
+	if c.accumulator < 0 {
+		c.accumulator = 0
+	}
+	c.elapsed += c.Step
+	c.frame++
+}
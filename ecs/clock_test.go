@@ -0,0 +1,68 @@
+package ecs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClockAdvance(t *testing.T) {
+	w := NewWorld()
+	inputs, steps := 0, 0
+	w.AddSystem("input", PhaseInput, systemFunc(func(float64) { inputs++ }))
+	w.AddSystem("step", PhaseSimulation, systemFunc(func(dt float64) {
+		if dt != DefaultStep {
+			t.Errorf("simulation got dt %v, want %v", dt, DefaultStep)
+		}
+		steps++
+	}))
+	clock := w.Clock()
+
+	// Less than a step accumulates without simulating
+	if n := w.Advance(DefaultStep * 0.75); n != 0 || steps != 0 {
+		t.Fatalf("Advance ran %d steps, want 0", n)
+	}
+	if a := clock.Alpha(); math.Abs(a-0.75) > 1e-9 {
+		t.Errorf("Alpha = %v, want 0.75", a)
+	}
+
+	// The leftover carries into the next call
+	if n := w.Advance(DefaultStep * 1.5); n != 2 || steps != 2 {
+		t.Fatalf("Advance ran %d steps, want 2", n)
+	}
+	if a := clock.Alpha(); math.Abs(a-0.25) > 1e-9 {
+		t.Errorf("Alpha = %v, want 0.25", a)
+	}
+	if inputs != 2 {
+		t.Errorf("input ran %d times, want once per Advance", inputs)
+	}
+	if got := clock.Frame(); got != 2 {
+		t.Errorf("Frame = %d, want 2", got)
+	}
+	if got := clock.Now(); math.Abs(got-2*DefaultStep) > 1e-9 {
+		t.Errorf("Now = %v, want %v", got, 2*DefaultStep)
+	}
+
+	clock.TimeScale = 0.5
+	if n := w.Advance(DefaultStep * 1.5); n != 1 {
+		t.Errorf("Advance at half speed ran %d steps, want 1", n)
+	}
+}
+
+func TestClockMaxSteps(t *testing.T) {
+	w := NewWorld()
+	steps := 0
+	w.AddSystem("step", PhaseSimulation, systemFunc(func(float64) { steps++ }))
+	clock := w.Clock()
+	clock.MaxSteps = 3
+
+	// A long stall runs MaxSteps and drops the rest of the backlog
+	if n := w.Advance(DefaultStep * 100); n != 3 || steps != 3 {
+		t.Fatalf("Advance ran %d steps, want 3", n)
+	}
+	if a := clock.Alpha(); a > 1e-9 {
+		t.Errorf("Alpha = %v after dropping the backlog, want 0", a)
+	}
+	if n := w.Advance(DefaultStep); n != 1 {
+		t.Errorf("Advance after a stall ran %d steps, want 1", n)
+	}
+}
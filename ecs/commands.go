@@ -0,0 +1,60 @@
+package ecs
+
+// Commands records structural changes made while systems are iterating so
+// they can be applied together at the next sync point. Operations are
+// applied in the order they were recorded.
+type Commands struct {
+	ops       []func(w *World)
+	destroyed map[EntityID]bool
+}
+
+func newCommands() *Commands {
+	return &Commands{
+		destroyed: make(map[EntityID]bool),
+	}
+}
+
+// Spawn queues build to run at the next flush. build usually calls one of
+// the factory functions and then adjusts the new entity's components.
+func (c *Commands) Spawn(build func(w *World)) {
+	c.ops = append(c.ops, build)
+}
+
+// Destroy queues id for destruction. Destroying the same entity twice in a
+// frame is harmless.
+func (c *Commands) Destroy(id EntityID) {
+	if c.destroyed[id] {
+		return
+	}
+	c.destroyed[id] = true
+	c.ops = append(c.ops, func(w *World) {
+		w.DestroyEntity(id)
+	})
+}
+
+// Destroyed reports whether id has a destroy pending, so systems can ignore
+// entities that are already on their way out this frame.
+func (c *Commands) Destroyed(id EntityID) bool {
+	return c.destroyed[id]
+}
+
+// RemoveComponents queues the removal of every component of id except those
+// held in the keep stores.
+func (c *Commands) RemoveComponents(id EntityID, keep ...ComponentStore) {
+	c.ops = append(c.ops, func(w *World) {
+		w.RemoveComponents(id, keep...)
+	})
+}
+
+func (c *Commands) apply(w *World) {
+	// Operations may queue further operations, e.g. a spawn that destroys
+	// something, so keep going until the buffer is drained
+	for len(c.ops) > 0 {
+		ops := c.ops
+		c.ops = nil
+		for _, op := range ops {
+			op(w)
+		}
+	}
+	clear(c.destroyed)
+}
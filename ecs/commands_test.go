@@ -0,0 +1,53 @@
+package ecs
+
+import "testing"
+
+func TestCommandsDestroyDedupe(t *testing.T) {
+	w := NewWorld()
+	id := w.CreateEntity()
+	Set(w, id, health{HP: 1})
+	cmd := w.Commands()
+
+	var spawned EntityID
+	cmd.Destroy(id)
+	cmd.Spawn(func(w *World) {
+		spawned = w.CreateEntity()
+	})
+	cmd.Destroy(id)
+
+	if got := len(cmd.ops); got != 2 {
+		t.Fatalf("%d operations queued, want 2", got)
+	}
+	if !cmd.Destroyed(id) {
+		t.Fatal("Destroyed is false with a destroy pending")
+	}
+	if !w.IsAlive(id) {
+		t.Fatal("entity destroyed before the flush")
+	}
+
+	w.Flush()
+	if w.IsAlive(id) || Has[health](w, id) {
+		t.Fatal("entity survived the flush")
+	}
+	if cmd.Destroyed(id) {
+		t.Error("destroy still pending after the flush")
+	}
+	// The spawn reused the slot, and the second destroy must not touch it
+	if spawned.Index() != id.Index() || !w.IsAlive(spawned) {
+		t.Errorf("spawned entity %v is not alive in slot %d", spawned, id.Index())
+	}
+}
+
+func TestCommandsNestedOps(t *testing.T) {
+	w := NewWorld()
+	id := w.CreateEntity()
+	cmd := w.Commands()
+
+	cmd.Spawn(func(w *World) {
+		w.Commands().Destroy(id)
+	})
+	w.Flush()
+	if w.IsAlive(id) {
+		t.Fatal("destroy queued during the flush was not applied")
+	}
+}
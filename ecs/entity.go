@@ -0,0 +1,31 @@
+package ecs
+
+import "fmt"
+
+// EntityID is a generational handle: the low 32 bits index a slot in the
+// world and the high 32 bits count how many times that slot has been reused.
+// A handle kept after its entity is destroyed never resolves to whatever
+// entity later takes over the slot.
+type EntityID uint64
+
+// NoEntity is the zero handle. It never refers to a live entity.
+const NoEntity EntityID = 0
+
+func newEntityID(index, generation uint32) EntityID {
+	return EntityID(uint64(generation)<<32 | uint64(index))
+}
+
+// Index returns the slot the entity occupies.
+func (id EntityID) Index() uint32 {
+	return uint32(id)
+}
+
+// Generation returns how many times the slot had been recycled when the
+// entity was created.
+func (id EntityID) Generation() uint32 {
+	return uint32(id >> 32)
+}
+
+func (id EntityID) String() string {
+	return fmt.Sprintf("%d:%d", id.Index(), id.Generation())
+}
@@ -0,0 +1,46 @@
+package ecs
+
+import "testing"
+
+func TestStaleHandle(t *testing.T) {
+	w := NewWorld()
+	old := w.CreateEntity()
+	Set(w, old, health{HP: 1})
+	w.DestroyEntity(old)
+
+	reused := w.CreateEntity()
+	Set(w, reused, health{HP: 2})
+	if reused.Index() != old.Index() {
+		t.Fatalf("new entity took slot %d, want the freed slot %d", reused.Index(), old.Index())
+	}
+	if reused.Generation() != old.Generation()+1 {
+		t.Fatalf("reused slot has generation %d, want %d", reused.Generation(), old.Generation()+1)
+	}
+
+	if w.IsAlive(old) {
+		t.Error("stale handle reports alive")
+	}
+	if h, ok := Get[health](w, old); ok {
+		t.Errorf("stale handle resolved to %v", h)
+	}
+
+	// Acting on the stale handle must leave the new entity alone
+	w.DestroyEntity(old)
+	Remove[health](w, old)
+	if h, ok := Get[health](w, reused); !ok || h.HP != 2 {
+		t.Fatalf("new entity's component is %v, %v after stale destroy", h, ok)
+	}
+	if !w.IsAlive(reused) {
+		t.Fatal("stale destroy killed the new entity")
+	}
+}
+
+func TestNoEntity(t *testing.T) {
+	w := NewWorld()
+	if w.IsAlive(NoEntity) {
+		t.Fatal("NoEntity is alive")
+	}
+	if id := w.CreateEntity(); id == NoEntity {
+		t.Fatal("CreateEntity returned NoEntity")
+	}
+}
@@ -0,0 +1,49 @@
+package ecs
+
+import "reflect"
+
+type eventHandlers[E any] struct {
+	handlers []func(E)
+}
+
+func handlersFor[E any](w *World) *eventHandlers[E] {
+	t := reflect.TypeOf((*E)(nil)).Elem()
+	if h, ok := w.eventHandlers[t]; ok {
+		return h.(*eventHandlers[E])
+	}
+	h := &eventHandlers[E]{}
+	w.eventHandlers[t] = h
+	return h
+}
+
+// Subscribe registers handler to receive every event of type E. Handlers run
+// when the world flushes, never in the middle of another system's update, so
+// they may freely create and destroy entities.
+func Subscribe[E any](w *World, handler func(E)) {
+	h := handlersFor[E](w)
+	h.handlers = append(h.handlers, handler)
+}
+
+// Publish queues event for delivery at the next flush. Events are delivered
+// in the order they were published, across all event types.
+func Publish[E any](w *World, event E) {
+	h := handlersFor[E](w)
+	w.events = append(w.events, func() {
+		for _, handler := range h.handlers {
+			handler(event)
+		}
+	})
+}
+
+// dispatchEvents delivers queued events and reports whether there were any.
+func (w *World) dispatchEvents() bool {
+	if len(w.events) == 0 {
+		return false
+	}
+	events := w.events
+	w.events = nil
+	for _, deliver := range events {
+		deliver()
+	}
+	return true
+}
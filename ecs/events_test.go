@@ -0,0 +1,37 @@
+package ecs
+
+import (
+	"slices"
+	"testing"
+)
+
+type hitEvent struct{ N int }
+
+type scoreEvent struct{ N int }
+
+func TestEventsDeliveredAtFlush(t *testing.T) {
+	w := NewWorld()
+	var got []int
+	Subscribe(w, func(e hitEvent) {
+		got = append(got, e.N)
+		if e.N == 1 {
+			// Events published by handlers arrive before Flush returns
+			Publish(w, scoreEvent{N: 10})
+		}
+	})
+	Subscribe(w, func(e scoreEvent) {
+		got = append(got, e.N)
+	})
+
+	Publish(w, hitEvent{N: 1})
+	Publish(w, scoreEvent{N: 2})
+	Publish(w, hitEvent{N: 3})
+	if len(got) != 0 {
+		t.Fatalf("events delivered before the flush: %v", got)
+	}
+
+	w.Flush()
+	if want := []int{1, 2, 3, 10}; !slices.Equal(got, want) {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package ecs
+
+import "slices"
+
+// Query selects the entities that have a component in every one of its
+// stores. Results are always visited in ascending
+// EntityID order so a simulation run is reproducible frame to frame.
+type Query struct {
+	with []ComponentStore
+	ids  []EntityID
+}
+
+// NewQuery builds a query over entities present in all of the given stores.
+func NewQuery(with ...ComponentStore) *Query {
+	return &Query{with: with}
+}
+
+// IDs returns a sorted snapshot of the matching entities. The slice is reused
+// by the next call to IDs or Each.
+func (q *Query) IDs() []EntityID {
+	q.ids = q.ids[:0]
+	if len(q.with) == 0 {
+		return q.ids
+	}
+
+	// Start from the smallest store so the candidate list stays short
+	smallest := q.with[0]
+	for _, store := range q.with[1:] {
+		if store.Len() < smallest.Len() {
+			smallest = store
+		}
+	}
+
+	q.ids = smallest.appendIDs(q.ids)
+	slices.Sort(q.ids)
+
+	matched := q.ids[:0]
+	for _, id := range q.ids {
+		if q.Matches(id) {
+			matched = append(matched, id)
+		}
+	}
+	q.ids = matched
+	return q.ids
+}
+
+// Matches reports whether id currently satisfies the query.
+func (q *Query) Matches(id EntityID) bool {
+	for _, store := range q.with {
+		if !store.Has(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// Each calls fn for every matching entity in ascending ID order. Entities
+// that stop matching part way through, for example because fn destroyed
+// them, are skipped.
+func (q *Query) Each(fn func(id EntityID)) {
+	for _, id := range q.IDs() {
+		if q.Matches(id) {
+			fn(id)
+		}
+	}
+}
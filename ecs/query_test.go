@@ -0,0 +1,47 @@
+package ecs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueryOrder(t *testing.T) {
+	w := NewWorld()
+	positions := Register[position](w)
+	healths := Register[health](w)
+
+	var want []EntityID
+	ids := make([]EntityID, 8)
+	for i := range ids {
+		ids[i] = w.CreateEntity()
+	}
+	// Add components out of order so storage order differs from ID order
+	for i := len(ids) - 1; i >= 0; i-- {
+		positions.Set(ids[i], position{})
+		if i%3 != 0 {
+			healths.Set(ids[i], health{})
+		}
+	}
+	for i, id := range ids {
+		if i%3 != 0 {
+			want = append(want, id)
+		}
+	}
+
+	q := NewQuery(positions, healths)
+	if got := q.IDs(); !slices.Equal(got, want) {
+		t.Fatalf("IDs = %v, want %v", got, want)
+	}
+
+	// Entities destroyed part way through are skipped
+	var visited []EntityID
+	q.Each(func(id EntityID) {
+		visited = append(visited, id)
+		if id == want[0] {
+			w.DestroyEntity(want[1])
+		}
+	})
+	if wantVisited := append([]EntityID{want[0]}, want[2:]...); !slices.Equal(visited, wantVisited) {
+		t.Errorf("Each visited %v, want %v", visited, wantVisited)
+	}
+}
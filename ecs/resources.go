@@ -0,0 +1,33 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SetResource stores a world-wide singleton, replacing any previous value of
+// the same type. Resources are looked up by type, so wrap plain values in a
+// named type or pointer to keep them distinct.
+func SetResource[T any](w *World, value T) {
+	w.resources[reflect.TypeOf((*T)(nil)).Elem()] = value
+}
+
+// LookupResource returns the resource of type T, if one has been set.
+func LookupResource[T any](w *World) (T, bool) {
+	value, ok := w.resources[reflect.TypeOf((*T)(nil)).Elem()]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return value.(T), true
+}
+
+// Resource returns the resource of type T. A missing resource is a setup
+// mistake, so it panics rather than handing back a zero value.
+func Resource[T any](w *World) T {
+	value, ok := LookupResource[T](w)
+	if !ok {
+		panic(fmt.Sprintf("ecs: no %v resource in world", reflect.TypeOf((*T)(nil)).Elem()))
+	}
+	return value
+}
@@ -0,0 +1,210 @@
+package ecs
+
+import "fmt"
+
+// Phase groups systems that run at the same stage of a frame. Phases always
+// run in the order they are declared here.
+type Phase int
+
+const (
+	PhaseInput Phase = iota
+	PhaseSimulation
+	PhasePostSimulation
+	PhaseRender
+	phaseCount
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseInput:
+		return "input"
+	case PhaseSimulation:
+		return "simulation"
+	case PhasePostSimulation:
+		return "post-simulation"
+	case PhaseRender:
+		return "render"
+	}
+	return fmt.Sprintf("Phase(%d)", int(p))
+}
+
+// SystemOption configures how a system is scheduled.
+type SystemOption func(*scheduledSystem)
+
+// After makes the system run after the named systems in the same phase.
+func After(names ...string) SystemOption {
+	return func(s *scheduledSystem) {
+		s.after = append(s.after, names...)
+	}
+}
+
+// Before makes the system run before the named systems in the same phase.
+func Before(names ...string) SystemOption {
+	return func(s *scheduledSystem) {
+		s.before = append(s.before, names...)
+	}
+}
+
+type scheduledSystem struct {
+	name   string
+	phase  Phase
+	system System
+	after  []string
+	before []string
+}
+
+type schedule struct {
+	systems       []*scheduledSystem
+	byName        map[string]*scheduledSystem
+	ordered       [phaseCount][]*scheduledSystem
+	phaseDisabled [phaseCount]bool
+	dirty         bool
+}
+
+func newSchedule() *schedule {
+	return &schedule{
+		byName: make(map[string]*scheduledSystem),
+	}
+}
+
+// AddSystem schedules system under name in the given phase. Names must be
+// unique; other systems refer to them in ordering constraints.
+func (w *World) AddSystem(name string, phase Phase, system System, opts ...SystemOption) {
+	if _, exists := w.schedule.byName[name]; exists {
+		panic(fmt.Sprintf("ecs: system %q already registered", name))
+	}
+	if phase < 0 || phase >= phaseCount {
+		panic(fmt.Sprintf("ecs: system %q has invalid phase %v", name, phase))
+	}
+
+	s := &scheduledSystem{
+		name:   name,
+		phase:  phase,
+		system: system,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	w.schedule.systems = append(w.schedule.systems, s)
+	w.schedule.byName[name] = s
+	w.schedule.dirty = true
+}
+
+// SetPhaseEnabled turns every system in a phase on or off, e.g. to freeze
+// the simulation while the game is over.
+func (w *World) SetPhaseEnabled(phase Phase, enabled bool) {
+	w.schedule.phaseDisabled[phase] = !enabled
+}
+
+// PhaseEnabled reports whether the systems in phase currently run.
+func (w *World) PhaseEnabled(phase Phase) bool {
+	return !w.schedule.phaseDisabled[phase]
+}
+
+// Update runs the input, simulation and post-simulation phases once with
+// the given dt, flushing commands and events after every system. Most callers
+// want Advance or Step, which keep the simulation on the fixed clock.
+func (w *World) Update(dt float64) {
+	for phase := PhaseInput; phase < PhaseRender; phase++ {
+		w.RunPhase(phase, dt)
+	}
+}
+
+// Advance feeds realDt seconds of host time into the clock. Input is sampled
+// once, then the simulation runs as many fixed steps as are due, which may
+// be none. It returns the number of steps run.
+func (w *World) Advance(realDt float64) int {
+	w.RunPhase(PhaseInput, realDt)
+
+	steps := w.Clock().accumulate(realDt)
+	for i := 0; i < steps; i++ {
+		w.simulate()
+	}
+	return steps
+}
+
+// Step samples input and runs exactly one fixed simulation step, regardless
+// of real time. Headless runs drive the world with Step.
+func (w *World) Step() {
+	w.RunPhase(PhaseInput, w.Clock().Step)
+	w.simulate()
+}
+
+func (w *World) simulate() {
+	clock := w.Clock()
+	w.RunPhase(PhaseSimulation, clock.Step)
+	w.RunPhase(PhasePostSimulation, clock.Step)
+	clock.tick()
+}
+
+// Render runs the render phase.
+func (w *World) Render() {
+	w.RunPhase(PhaseRender, 0)
+}
+
+// RunPhase runs every system in phase in dependency order. A system
+// disabling its own phase stops the rest of the phase from running.
+func (w *World) RunPhase(phase Phase, dt float64) {
+	w.schedule.resolve()
+	for _, s := range w.schedule.ordered[phase] {
+		if w.schedule.phaseDisabled[phase] {
+			return
+		}
+		w.RunSystem(s.system, dt)
+	}
+}
+
+// resolve orders each phase so every After/Before constraint holds. Systems
+// without constraints between them keep their registration order.
+func (sc *schedule) resolve() {
+	if !sc.dirty {
+		return
+	}
+
+	for phase := range sc.ordered {
+		sc.ordered[phase] = sc.ordered[phase][:0]
+	}
+
+	// Collect the edges: deps[s] lists systems that must run before s
+	deps := make(map[*scheduledSystem][]*scheduledSystem)
+	for _, s := range sc.systems {
+		for _, name := range s.after {
+			if other, ok := sc.byName[name]; ok && other.phase == s.phase {
+				deps[s] = append(deps[s], other)
+			}
+		}
+		for _, name := range s.before {
+			if other, ok := sc.byName[name]; ok && other.phase == s.phase {
+				deps[other] = append(deps[other], s)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[*scheduledSystem]int)
+	var visit func(s *scheduledSystem)
+	visit = func(s *scheduledSystem) {
+		switch state[s] {
+		case done:
+			return
+		case visiting:
+			panic(fmt.Sprintf("ecs: ordering cycle involving system %q", s.name))
+		}
+		state[s] = visiting
+		for _, dep := range deps[s] {
+			visit(dep)
+		}
+		state[s] = done
+		sc.ordered[s.phase] = append(sc.ordered[s.phase], s)
+	}
+	for _, s := range sc.systems {
+		visit(s)
+	}
+
+	sc.dirty = false
+}
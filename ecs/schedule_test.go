@@ -0,0 +1,70 @@
+package ecs
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type systemFunc func(dt float64)
+
+func (f systemFunc) Update(dt float64) { f(dt) }
+
+// recorder returns a system that appends name to ran when it updates.
+func recorder(ran *[]string, name string) System {
+	return systemFunc(func(float64) { *ran = append(*ran, name) })
+}
+
+func TestScheduleOrdering(t *testing.T) {
+	w := NewWorld()
+	var ran []string
+	w.AddSystem("render", PhaseRender, recorder(&ran, "render"))
+	w.AddSystem("score", PhasePostSimulation, recorder(&ran, "score"))
+	w.AddSystem("collision", PhaseSimulation, recorder(&ran, "collision"), After("movement"))
+	w.AddSystem("movement", PhaseSimulation, recorder(&ran, "movement"))
+	w.AddSystem("player", PhaseSimulation, recorder(&ran, "player"), Before("movement"))
+	w.AddSystem("lifetime", PhaseSimulation, recorder(&ran, "lifetime"))
+	w.AddSystem("input", PhaseInput, recorder(&ran, "input"), After("score"))
+
+	w.Update(DefaultStep)
+	// Constraints across phases are ignored; unconstrained systems keep
+	// their registration order
+	want := []string{"input", "player", "movement", "collision", "lifetime", "score"}
+	if !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestScheduleCycle(t *testing.T) {
+	w := NewWorld()
+	var ran []string
+	w.AddSystem("a", PhaseSimulation, recorder(&ran, "a"), After("c"))
+	w.AddSystem("b", PhaseSimulation, recorder(&ran, "b"), After("a"))
+	w.AddSystem("c", PhaseSimulation, recorder(&ran, "c"), Before("a"), After("b"))
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "ordering cycle") {
+			t.Errorf("recovered %q, want an ordering cycle panic", msg)
+		}
+	}()
+	w.Update(DefaultStep)
+	t.Error("cyclic schedule ran")
+}
+
+func TestSchedulePhaseDisabled(t *testing.T) {
+	w := NewWorld()
+	var ran []string
+	w.AddSystem("over", PhaseSimulation, systemFunc(func(float64) {
+		ran = append(ran, "over")
+		w.SetPhaseEnabled(PhaseSimulation, false)
+	}))
+	w.AddSystem("movement", PhaseSimulation, recorder(&ran, "movement"))
+	w.AddSystem("score", PhasePostSimulation, recorder(&ran, "score"))
+
+	w.Update(DefaultStep)
+	w.Update(DefaultStep)
+	if want := []string{"over", "score", "score"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
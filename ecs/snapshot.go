@@ -0,0 +1,282 @@
+package ecs
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SnapshotVersion is bumped whenever the snapshot layout changes in a way
+// older builds can't read. Snapshots older than minSnapshotVersion hold
+// component data this build can no longer use and are rejected.
+const (
+	SnapshotVersion    = 8
+	minSnapshotVersion = 8 // Ships and saucers have rigid bodies
+)
+
+// binaryMagic prefixes binary snapshots so Load can tell them from JSON.
+var binaryMagic = []byte("ECSW")
+
+// Snapshotter is implemented by systems that keep state outside of
+// components, such as timers, so it is saved and restored with the world.
+type Snapshotter interface {
+	// SnapshotState returns a value holding the system's state. It must be
+	// encodable with both encoding/json and encoding/gob.
+	SnapshotState() any
+	// RestoreState reads the state back; decode fills the value passed to it.
+	RestoreState(decode func(v any) error) error
+}
+
+type clockState struct {
+	Elapsed     float64 `json:"elapsed"`
+	Frame       uint64  `json:"frame"`
+	Accumulator float64 `json:"accumulator"`
+	TimeScale   float64 `json:"time_scale"`
+}
+
+type entityState struct {
+	Generations []uint32 `json:"generations"`
+	Alive       []bool   `json:"alive"`
+	Free        []uint32 `json:"free"`
+}
+
+type snapshotHeader struct {
+	Version  int         `json:"version"`
+	Clock    clockState  `json:"clock"`
+	Entities entityState `json:"entities"`
+}
+
+type jsonSnapshot struct {
+	snapshotHeader
+	Components map[string]json.RawMessage `json:"components"`
+	Systems    map[string]json.RawMessage `json:"systems,omitempty"`
+}
+
+// binarySnapshot names its header field because gob skips embedded fields
+// of unexported types.
+type binarySnapshot struct {
+	Header     snapshotHeader
+	Components map[string][]byte
+	Systems    map[string][]byte
+}
+
+func gobMarshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobUnmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// SaveJSON serializes every entity, component and stateful system. The
+// output is meant for debugging; use SaveBinary for storage.
+func (w *World) SaveJSON() ([]byte, error) {
+	components, systems, err := w.encodeParts(json.Marshal)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := jsonSnapshot{
+		snapshotHeader: w.snapshotHeader(),
+		Components:     make(map[string]json.RawMessage, len(components)),
+		Systems:        make(map[string]json.RawMessage, len(systems)),
+	}
+	for name, data := range components {
+		snapshot.Components[name] = data
+	}
+	for name, data := range systems {
+		snapshot.Systems[name] = data
+	}
+	return json.MarshalIndent(snapshot, "", "  ")
+}
+
+// SaveBinary serializes the world in a compact binary form.
+func (w *World) SaveBinary() ([]byte, error) {
+	components, systems, err := w.encodeParts(gobMarshal)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := gobMarshal(binarySnapshot{
+		Header:     w.snapshotHeader(),
+		Components: components,
+		Systems:    systems,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, binaryMagic...), data...), nil
+}
+
+// Load replaces the world's entities, components, clock and system state
+// with a snapshot produced by SaveJSON or SaveBinary. Every component type in
+// the snapshot must already be registered, which happens when the systems
+// are constructed. If the snapshot can't be loaded the world is left as it
+// was.
+func (w *World) Load(data []byte) error {
+	return w.load(data, true)
+}
+
+// load does the work of Load. When rollback is set a system that fails to
+// restore its state puts the world back as it was; the rollback itself is
+// loaded without, so a system that can't read its own state can't recurse.
+func (w *World) load(data []byte, rollback bool) error {
+	var (
+		header     snapshotHeader
+		components map[string][]byte
+		systems    map[string][]byte
+		unmarshal  func([]byte, any) error
+	)
+
+	if bytes.HasPrefix(data, binaryMagic) {
+		var snapshot binarySnapshot
+		if err := gobUnmarshal(data[len(binaryMagic):], &snapshot); err != nil {
+			return fmt.Errorf("ecs: decoding snapshot: %w", err)
+		}
+		header, components, systems = snapshot.Header, snapshot.Components, snapshot.Systems
+		unmarshal = gobUnmarshal
+	} else {
+		var snapshot jsonSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return fmt.Errorf("ecs: decoding snapshot: %w", err)
+		}
+		header = snapshot.snapshotHeader
+		components = make(map[string][]byte, len(snapshot.Components))
+		for name, raw := range snapshot.Components {
+			components[name] = raw
+		}
+		systems = make(map[string][]byte, len(snapshot.Systems))
+		for name, raw := range snapshot.Systems {
+			systems[name] = raw
+		}
+		unmarshal = json.Unmarshal
+	}
+
+	if header.Version < minSnapshotVersion || header.Version > SnapshotVersion {
+		return fmt.Errorf("ecs: unsupported snapshot version %d", header.Version)
+	}
+	e := header.Entities
+	if len(e.Generations) == 0 || len(e.Generations) != len(e.Alive) {
+		return errors.New("ecs: snapshot has malformed entity table")
+	}
+
+	// Decode every store before touching the world, so a corrupt or
+	// truncated snapshot leaves it as it was
+	loads := make([]func(), 0, len(components))
+	for name, data := range components {
+		store, ok := w.storesByName[name]
+		if !ok {
+			return fmt.Errorf("ecs: snapshot contains unregistered component %s", name)
+		}
+		apply, err := store.decode(unmarshal, data)
+		if err != nil {
+			return fmt.Errorf("ecs: decoding %s: %w", name, err)
+		}
+		loads = append(loads, apply)
+	}
+
+	// System state can only be checked by restoring it, so keep the world
+	// as it is to put back if that fails
+	var backup []byte
+	if rollback {
+		var err error
+		if backup, err = w.SaveBinary(); err != nil {
+			return err
+		}
+	}
+	commands, events := w.commands, w.events
+
+	// Everything decoded, so start replacing state
+	w.commands = newCommands()
+	w.events = nil
+	w.generations = e.Generations
+	w.alive = e.Alive
+	w.free = e.Free
+
+	clock := w.Clock()
+	clock.elapsed = header.Clock.Elapsed
+	clock.frame = header.Clock.Frame
+	clock.accumulator = header.Clock.Accumulator
+	clock.TimeScale = header.Clock.TimeScale
+
+	for _, store := range w.stores {
+		store.clear()
+	}
+	for _, apply := range loads {
+		apply()
+	}
+
+	if err := w.restoreSystems(systems, unmarshal); err != nil {
+		if !rollback {
+			return err
+		}
+		rollbackErr := w.load(backup, false)
+		w.commands, w.events = commands, events
+		return errors.Join(err, rollbackErr)
+	}
+	return nil
+}
+
+func (w *World) restoreSystems(systems map[string][]byte, unmarshal func([]byte, any) error) error {
+	for name, data := range systems {
+		s, ok := w.schedule.byName[name]
+		if !ok {
+			continue
+		}
+		if snapshotter, ok := s.system.(Snapshotter); ok {
+			decode := func(v any) error { return unmarshal(data, v) }
+			if err := snapshotter.RestoreState(decode); err != nil {
+				return fmt.Errorf("ecs: restoring system %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *World) snapshotHeader() snapshotHeader {
+	clock := w.Clock()
+	return snapshotHeader{
+		Version: SnapshotVersion,
+		Clock: clockState{
+			Elapsed:     clock.elapsed,
+			Frame:       clock.frame,
+			Accumulator: clock.accumulator,
+			TimeScale:   clock.TimeScale,
+		},
+		Entities: entityState{
+			Generations: w.generations,
+			Alive:       w.alive,
+			Free:        w.free,
+		},
+	}
+}
+
+func (w *World) encodeParts(marshal func(any) ([]byte, error)) (components, systems map[string][]byte, err error) {
+	components = make(map[string][]byte, len(w.storesByName))
+	for name, store := range w.storesByName {
+		if store.Len() == 0 {
+			continue
+		}
+		if components[name], err = store.encode(marshal); err != nil {
+			return nil, nil, fmt.Errorf("ecs: encoding %s: %w", name, err)
+		}
+	}
+
+	systems = make(map[string][]byte)
+	for _, s := range w.schedule.systems {
+		snapshotter, ok := s.system.(Snapshotter)
+		if !ok {
+			continue
+		}
+		if systems[s.name], err = marshal(snapshotter.SnapshotState()); err != nil {
+			return nil, nil, fmt.Errorf("ecs: encoding system %s: %w", s.name, err)
+		}
+	}
+	return components, systems, nil
+}
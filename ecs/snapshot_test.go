@@ -0,0 +1,164 @@
+package ecs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// counter is a system with state kept outside of components.
+type counter struct {
+	Count int
+	fail  bool
+}
+
+func (c *counter) Update(float64) { c.Count++ }
+
+func (c *counter) SnapshotState() any { return c.Count }
+
+func (c *counter) RestoreState(decode func(v any) error) error {
+	if c.fail {
+		return errors.New("refused")
+	}
+	return decode(&c.Count)
+}
+
+// snapshotWorld builds a world with a few entities, a freed slot and some
+// system state.
+func snapshotWorld() (*World, *counter) {
+	w := NewWorld()
+	c := &counter{}
+	w.AddSystem("counter", PhaseSimulation, c)
+
+	a := w.CreateEntity()
+	b := w.CreateEntity()
+	Set(w, a, position{X: 1, Y: 2})
+	Set(w, a, health{HP: 3})
+	Set(w, b, position{X: 4, Y: 5})
+	w.DestroyEntity(w.CreateEntity())
+
+	for i := 0; i < 5; i++ {
+		w.Step()
+	}
+	w.Advance(DefaultStep / 2)
+	return w, c
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	for _, format := range []struct {
+		name string
+		save func(w *World) ([]byte, error)
+	}{
+		{"json", (*World).SaveJSON},
+		{"binary", (*World).SaveBinary},
+	} {
+		t.Run(format.name, func(t *testing.T) {
+			src, _ := snapshotWorld()
+			data, err := format.save(src)
+			if err != nil {
+				t.Fatalf("saving: %v", err)
+			}
+
+			dst := NewWorld()
+			Register[position](dst)
+			Register[health](dst)
+			c := &counter{}
+			dst.AddSystem("counter", PhaseSimulation, c)
+			if err := dst.Load(data); err != nil {
+				t.Fatalf("loading: %v", err)
+			}
+
+			if c.Count != 5 {
+				t.Errorf("system state is %d, want 5", c.Count)
+			}
+			if got, want := dst.Clock().Frame(), src.Clock().Frame(); got != want {
+				t.Errorf("Frame = %d, want %d", got, want)
+			}
+			if got, want := dst.Clock().Alpha(), src.Clock().Alpha(); got != want {
+				t.Errorf("Alpha = %v, want %v", got, want)
+			}
+
+			// The reloaded world saves back to the same bytes, and hands
+			// out the same IDs from here on
+			again, err := format.save(dst)
+			if err != nil {
+				t.Fatalf("saving reloaded world: %v", err)
+			}
+			if format.name == "json" && !bytes.Equal(again, data) {
+				t.Errorf("reloaded world saved as\n%s\nwant\n%s", again, data)
+			}
+			if got, want := dst.CreateEntity(), src.CreateEntity(); got != want {
+				t.Errorf("next entity is %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSnapshotVersion(t *testing.T) {
+	w, _ := snapshotWorld()
+	data, err := w.SaveJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, version := range []int{minSnapshotVersion - 1, SnapshotVersion + 1} {
+		var raw map[string]any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			t.Fatal(err)
+		}
+		raw["version"] = version
+		old, err := json.Marshal(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Load(old); err == nil {
+			t.Errorf("loaded a version %d JSON snapshot", version)
+		}
+
+		header := w.snapshotHeader()
+		header.Version = version
+		bin, err := gobMarshal(binarySnapshot{Header: header})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Load(append(append([]byte{}, binaryMagic...), bin...)); err == nil {
+			t.Errorf("loaded a version %d binary snapshot", version)
+		}
+	}
+}
+
+func TestSnapshotLoadFailureKeepsWorld(t *testing.T) {
+	src, _ := snapshotWorld()
+	data, err := src.SaveBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWorld()
+	c := &counter{Count: 99}
+	w.AddSystem("counter", PhaseSimulation, c)
+	id := w.CreateEntity()
+	Set(w, id, position{X: 7})
+	Register[health](w)
+	before, err := w.SaveJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.fail = true
+	if err := w.Load(data); err == nil {
+		t.Fatal("load succeeded although the system refused its state")
+	}
+	if err := w.Load(data[:len(data)/2]); err == nil {
+		t.Fatal("loaded a truncated snapshot")
+	}
+
+	after, err := w.SaveJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Errorf("failed loads changed the world:\n%s\nwant\n%s", after, before)
+	}
+}
@@ -0,0 +1,245 @@
+package ecs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ComponentStore is the type-erased view of a Store that the world uses to
+// clean up components when an entity is destroyed and to save snapshots.
+type ComponentStore interface {
+	Name() string
+	Has(id EntityID) bool
+	Remove(id EntityID)
+	Len() int
+	appendIDs(dst []EntityID) []EntityID
+	clear()
+	encode(marshal func(any) ([]byte, error)) ([]byte, error)
+	decode(unmarshal func([]byte, any) error, data []byte) (apply func(), err error)
+	decodeJSON(data []byte) (any, error)
+	setAny(id EntityID, value any)
+}
+
+// Store holds every component of type T, packed densely so systems can
+// iterate and read values without boxing them in interface{}. Lookups go
+// through a sparse array indexed by entity slot, so they cost no more than
+// indexing two slices.
+type Store[T any] struct {
+	name   string
+	ids    []EntityID
+	values []T
+	sparse []int32 // Position in ids and values plus one, by entity slot; 0 when absent
+}
+
+// storeData is the serialized form of a Store.
+type storeData[T any] struct {
+	IDs    []EntityID `json:"ids"`
+	Values []T        `json:"values"`
+}
+
+func newStore[T any](name string) *Store[T] {
+	return &Store[T]{name: name}
+}
+
+// Register returns the world's store for component type T, creating it on
+// first use. Systems call this once in their constructor and keep the result.
+func Register[T any](w *World) *Store[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if store, ok := w.stores[t]; ok {
+		return store.(*Store[T])
+	}
+	store := newStore[T](t.String())
+	w.stores[t] = store
+	w.storesByName[store.name] = store
+	return store
+}
+
+// Name returns the component's type name, e.g. "components.Position". It
+// identifies the store in snapshots.
+func (s *Store[T]) Name() string {
+	return s.name
+}
+
+// Get returns the component for id and whether the entity has one.
+func (s *Store[T]) Get(id EntityID) (T, bool) {
+	if i, ok := s.lookup(id); ok {
+		return s.values[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// Set adds or replaces the component for id.
+func (s *Store[T]) Set(id EntityID, value T) {
+	slot := int(id.Index())
+	if slot < len(s.sparse) && s.sparse[slot] > 0 {
+		i := int(s.sparse[slot]) - 1
+		switch {
+		case s.ids[i] == id:
+			s.values[i] = value
+		case s.ids[i].Generation() < id.Generation():
+			// Left behind by an earlier entity in the slot
+			s.ids[i] = id
+			s.values[i] = value
+		}
+		// Otherwise id is a stale handle to an entity that has since been
+		// destroyed and its slot reused
+		return
+	}
+
+	if slot >= len(s.sparse) {
+		s.sparse = append(s.sparse, make([]int32, slot+1-len(s.sparse))...)
+	}
+	s.sparse[slot] = int32(len(s.values) + 1)
+	s.ids = append(s.ids, id)
+	s.values = append(s.values, value)
+}
+
+// Has reports whether id has a component in this store.
+func (s *Store[T]) Has(id EntityID) bool {
+	_, ok := s.lookup(id)
+	return ok
+}
+
+// Remove deletes the component for id, if any.
+func (s *Store[T]) Remove(id EntityID) {
+	i, ok := s.lookup(id)
+	if !ok {
+		return
+	}
+
+	// Move the last element into the hole to keep storage packed
+	last := len(s.values) - 1
+	if i != last {
+		s.ids[i] = s.ids[last]
+		s.values[i] = s.values[last]
+		s.sparse[s.ids[i].Index()] = int32(i + 1)
+	}
+
+	var zero T
+	s.values[last] = zero
+	s.ids = s.ids[:last]
+	s.values = s.values[:last]
+	s.sparse[id.Index()] = 0
+}
+
+// lookup returns where id's component is stored.
+func (s *Store[T]) lookup(id EntityID) (int, bool) {
+	slot := int(id.Index())
+	if slot >= len(s.sparse) {
+		return 0, false
+	}
+	i := int(s.sparse[slot]) - 1
+	if i < 0 || s.ids[i] != id {
+		return 0, false
+	}
+	return i, true
+}
+
+// Len returns the number of components in the store.
+func (s *Store[T]) Len() int {
+	return len(s.values)
+}
+
+func (s *Store[T]) appendIDs(dst []EntityID) []EntityID {
+	return append(dst, s.ids...)
+}
+
+func (s *Store[T]) clear() {
+	s.ids = s.ids[:0]
+	s.values = s.values[:0]
+	clear(s.sparse)
+}
+
+func (s *Store[T]) encode(marshal func(any) ([]byte, error)) ([]byte, error) {
+	return marshal(storeData[T]{IDs: s.ids, Values: s.values})
+}
+
+func (s *Store[T]) decodeJSON(data []byte) (any, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (s *Store[T]) setAny(id EntityID, value any) {
+	s.Set(id, value.(T))
+}
+
+// decode reads the serialized form of the store without changing it. The
+// returned apply replaces the store's contents with what was read.
+func (s *Store[T]) decode(unmarshal func([]byte, any) error, data []byte) (func(), error) {
+	var d storeData[T]
+	if err := unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	if len(d.IDs) != len(d.Values) {
+		return nil, fmt.Errorf("ecs: %s has %d ids but %d values", s.name, len(d.IDs), len(d.Values))
+	}
+
+	return func() {
+		s.clear()
+		for i, id := range d.IDs {
+			s.Set(id, d.Values[i])
+		}
+	}, nil
+}
+
+// Each calls fn for every component in the store. Entries are visited from
+// the end of the packed storage so fn may remove the entity it was given.
+func (s *Store[T]) Each(fn func(id EntityID, value T)) {
+	for i := len(s.values) - 1; i >= 0; i-- {
+		if i >= len(s.values) {
+			continue
+		}
+		fn(s.ids[i], s.values[i])
+	}
+}
+
+// SetComponent stores value in the store registered for its dynamic type.
+// It is the untyped counterpart of Set for data-driven code such as prefabs.
+func (w *World) SetComponent(id EntityID, value any) error {
+	store, ok := w.stores[reflect.TypeOf(value)]
+	if !ok {
+		return fmt.Errorf("ecs: component type %T is not registered", value)
+	}
+	store.setAny(id, value)
+	return nil
+}
+
+// DecodeComponent decodes data into a value of the component type
+// registered under name, e.g. "components.Position". The value can be
+// stored on any number of entities with SetComponent.
+func (w *World) DecodeComponent(name string, data []byte) (any, error) {
+	store, ok := w.storesByName[name]
+	if !ok {
+		return nil, fmt.Errorf("ecs: unknown component %s", name)
+	}
+	value, err := store.decodeJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("ecs: decoding %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// Set stores value as the T component of id, registering the store if needed.
+func Set[T any](w *World, id EntityID, value T) {
+	Register[T](w).Set(id, value)
+}
+
+// Get returns the T component of id.
+func Get[T any](w *World, id EntityID) (T, bool) {
+	return Register[T](w).Get(id)
+}
+
+// Has reports whether id has a T component.
+func Has[T any](w *World, id EntityID) bool {
+	return Register[T](w).Has(id)
+}
+
+// Remove deletes the T component of id.
+func Remove[T any](w *World, id EntityID) {
+	Register[T](w).Remove(id)
+}
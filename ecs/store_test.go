@@ -0,0 +1,85 @@
+package ecs
+
+import "testing"
+
+type position struct {
+	X, Y float64
+}
+
+type health struct {
+	HP int
+}
+
+func TestStoreSetGetRemove(t *testing.T) {
+	w := NewWorld()
+	positions := Register[position](w)
+
+	ids := make([]EntityID, 4)
+	for i := range ids {
+		ids[i] = w.CreateEntity()
+		positions.Set(ids[i], position{X: float64(i)})
+	}
+	positions.Set(ids[2], position{X: 20})
+
+	// Removing from the middle moves the last component into the hole
+	positions.Remove(ids[1])
+	if positions.Has(ids[1]) {
+		t.Fatal("removed component is still present")
+	}
+	if got := positions.Len(); got != 3 {
+		t.Fatalf("Len = %d, want 3", got)
+	}
+	want := map[EntityID]float64{ids[0]: 0, ids[2]: 20, ids[3]: 3}
+	for id, x := range want {
+		got, ok := positions.Get(id)
+		if !ok || got.X != x {
+			t.Errorf("Get(%v) = %v, %v; want X %v", id, got, ok, x)
+		}
+	}
+
+	if Register[position](w) != positions {
+		t.Fatal("Register returned a second store for the same type")
+	}
+	if got := positions.Name(); got != "ecs.position" {
+		t.Errorf("Name = %q, want ecs.position", got)
+	}
+}
+
+func TestStoreEachRemove(t *testing.T) {
+	w := NewWorld()
+	healths := Register[health](w)
+	for i := 0; i < 5; i++ {
+		healths.Set(w.CreateEntity(), health{HP: i})
+	}
+
+	visited := 0
+	healths.Each(func(id EntityID, h health) {
+		visited++
+		if h.HP%2 == 0 {
+			healths.Remove(id)
+		}
+	})
+	if visited != 5 {
+		t.Errorf("Each visited %d components, want 5", visited)
+	}
+	healths.Each(func(id EntityID, h health) {
+		if h.HP%2 == 0 {
+			t.Errorf("component %v with HP %d survived removal", id, h.HP)
+		}
+	})
+	if got := healths.Len(); got != 2 {
+		t.Errorf("Len = %d, want 2", got)
+	}
+}
+
+func TestDestroyEntityRemovesComponents(t *testing.T) {
+	w := NewWorld()
+	id := w.CreateEntity()
+	Set(w, id, position{X: 1})
+	Set(w, id, health{HP: 3})
+
+	w.DestroyEntity(id)
+	if Has[position](w, id) || Has[health](w, id) {
+		t.Fatal("destroyed entity still has components")
+	}
+}
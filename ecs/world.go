@@ -1,87 +1,129 @@
 package ecs
 
 import (
-	"fmt"
 	"image/color"
+	"reflect"
 )
 
-type EntityID int
-
 type System interface {
 	Update(dt float64)
 }
 
 type World struct {
-	nextEntityID    EntityID
-	Components      map[string]map[EntityID]interface{}
-	systems         []System
-	entities        map[EntityID]bool
+	generations     []uint32
+	alive           []bool
+	free            []uint32
+	stores          map[reflect.Type]ComponentStore
+	storesByName    map[string]ComponentStore
+	schedule        *schedule
+	resources       map[reflect.Type]any
+	commands        *Commands
+	eventHandlers   map[reflect.Type]any
+	events          []func()
 	BackgroundColor color.Color
 }
 
 func NewWorld() *World {
-	return &World{
-		nextEntityID: 1,
-		Components: map[string]map[EntityID]interface{}{
-			"components.Position":     make(map[EntityID]interface{}),
-			"components.Velocity":     make(map[EntityID]interface{}),
-			"components.Rotation":     make(map[EntityID]interface{}),
-			"components.Renderable":   make(map[EntityID]interface{}),
-			"components.Player":       make(map[EntityID]interface{}),
-			"components.Input":        make(map[EntityID]interface{}),
-			"components.Lifetime":     make(map[EntityID]interface{}),
-			"components.Collider":     make(map[EntityID]interface{}),
-			"components.Asteroid":     make(map[EntityID]interface{}),
-			"components.Explosion":    make(map[EntityID]interface{}),
-			"components.Invulnerable": make(map[EntityID]interface{}),
-			"components.Bullet":       make(map[EntityID]interface{}),
-		},
-		systems:         make([]System, 0),
-		entities:        make(map[EntityID]bool),
+	w := &World{
+		// Slot 0 is never handed out so the zero EntityID stays invalid
+		generations:     []uint32{0},
+		alive:           []bool{false},
+		stores:          make(map[reflect.Type]ComponentStore),
+		storesByName:    make(map[string]ComponentStore),
+		schedule:        newSchedule(),
+		resources:       make(map[reflect.Type]any),
+		commands:        newCommands(),
+		eventHandlers:   make(map[reflect.Type]any),
 		BackgroundColor: color.Black,
 	}
+	SetResource(w, NewClock(DefaultStep))
+	return w
 }
 
-func (w *World) AddSystem(system System) {
-	w.systems = append(w.systems, system)
+func (w *World) CreateEntity() EntityID {
+	// Reuse a freed slot when one is available
+	if n := len(w.free); n > 0 {
+		index := w.free[n-1]
+		w.free = w.free[:n-1]
+		w.alive[index] = true
+		return newEntityID(index, w.generations[index])
+	}
+
+	index := uint32(len(w.generations))
+	w.generations = append(w.generations, 0)
+	w.alive = append(w.alive, true)
+	return newEntityID(index, 0)
 }
 
-func (w *World) CreateEntity() EntityID {
-	id := w.nextEntityID
-	w.nextEntityID++
-	w.entities[id] = true
-	return id
+// IsAlive reports whether id refers to an entity that has not been
+// destroyed. Handles to a destroyed entity stay dead even after the slot is
+// reused.
+func (w *World) IsAlive(id EntityID) bool {
+	index := id.Index()
+	return int(index) < len(w.generations) &&
+		w.alive[index] &&
+		w.generations[index] == id.Generation()
 }
 
 func (w *World) DestroyEntity(id EntityID) {
-	if !w.entities[id] {
+	if !w.IsAlive(id) {
 		return
 	}
 
 	// Remove all components for this entity
-	for _, components := range w.Components {
-		delete(components, id)
+	for _, store := range w.stores {
+		store.Remove(id)
 	}
 
-	delete(w.entities, id)
+	// Bump the generation so outstanding handles stop resolving
+	index := id.Index()
+	w.alive[index] = false
+	w.generations[index]++
+	w.free = append(w.free, index)
 }
 
-func (w *World) AddComponent(id EntityID, component interface{}) {
-	componentType := fmt.Sprintf("%T", component)
-	if _, ok := w.Components[componentType]; !ok {
-		w.Components[componentType] = make(map[EntityID]interface{})
+// RemoveComponents strips every component from id except those held in the
+// keep stores, leaving the entity itself alive.
+func (w *World) RemoveComponents(id EntityID, keep ...ComponentStore) {
+	for _, store := range w.stores {
+		kept := false
+		for _, k := range keep {
+			if store == k {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			store.Remove(id)
+		}
 	}
-	w.Components[componentType][id] = component
 }
 
-func (w *World) RemoveComponent(id EntityID, componentType string) {
-	if components, ok := w.Components[componentType]; ok {
-		delete(components, id)
-	}
+// Clock returns the world's simulation clock. It is an ordinary resource, so
+// it can be replaced with SetResource.
+func (w *World) Clock() *Clock {
+	return Resource[*Clock](w)
+}
+
+// Commands returns the world's deferred command buffer.
+func (w *World) Commands() *Commands {
+	return w.commands
 }
 
-func (w *World) Update(dt float64) {
-	for _, system := range w.systems {
-		system.Update(dt)
+// Flush applies every queued command and delivers pending events. It is the
+// world's sync point between systems. Handlers may queue more commands and
+// events, which are processed before Flush returns.
+func (w *World) Flush() {
+	for {
+		w.commands.apply(w)
+		if !w.dispatchEvents() {
+			return
+		}
 	}
 }
+
+// RunSystem updates a single system and flushes whatever it queued.
+func (w *World) RunSystem(system System, dt float64) {
+	system.Update(dt)
+	w.Flush()
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+)
+
+// AsteroidDestroyed is published when an asteroid is shot or otherwise
+// broken up. By is the player credited with the kill, or ecs.NoEntity.
+type AsteroidDestroyed struct {
+	Asteroid ecs.EntityID
+	Size     int
+	X, Y     float64
+	By       ecs.EntityID
+}
+
+// SaucerDestroyed is published when a saucer is shot down or crashes. By
+// is the player credited with the kill, or ecs.NoEntity.
+type SaucerDestroyed struct {
+	Saucer ecs.EntityID
+	Points int
+	X, Y   float64
+	By     ecs.EntityID
+}
+
+// PowerUpCollected is published when a ship picks up a power-up.
+type PowerUpCollected struct {
+	Ship ecs.EntityID
+	Kind components.PowerUpKind
+}
+
+// ShipHit is published when a player's ship is destroyed.
+type ShipHit struct {
+	Ship      ecs.EntityID
+	X, Y      float64
+	LivesLeft int
+}
+
+// BulletFired is published when a ship fires.
+type BulletFired struct {
+	Shooter ecs.EntityID
+	X, Y    float64
+	Angle   float64
+}
+
+// HyperspaceEntered is published when a ship vanishes into hyperspace.
+type HyperspaceEntered struct {
+	Ship ecs.EntityID
+	X, Y float64
+}
+
+// HyperspaceExited is published when a ship reappears from hyperspace.
+// Destroyed is set when it blew up on re-entry.
+type HyperspaceExited struct {
+	Ship      ecs.EntityID
+	X, Y      float64
+	Destroyed bool
+}
+
+// ExtraLife is published when a player earns an extra life by scoring.
+type ExtraLife struct {
+	Player ecs.EntityID
+	Lives  int // Lives the player now has
+	Score  int // Score that earned it
+}
+
+// GameOver is published when a player loses their last life.
+type GameOver struct {
+	Player ecs.EntityID
+	Score  int
+}
+
+// GameRestarted is published when a player starts a new game after a game
+// over.
+type GameRestarted struct {
+	Player ecs.EntityID
+}
+
+// WaveStarted is published when a new wave of asteroids arrives.
+type WaveStarted struct {
+	Wave      int
+	Asteroids int
+}
+
+// WaveCleared is published when the last asteroid of a wave is destroyed.
+type WaveCleared struct {
+	Wave int
+}
@@ -0,0 +1,57 @@
+package frontend
+
+import (
+	"github.com/bobbyhiddn/ecs-asteroids/systems"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// EbitenInput reads the keyboard, mouse and touch screen through Ebiten.
+type EbitenInput struct{}
+
+// Poll implements systems.InputSource.
+func (EbitenInput) Poll() systems.InputState {
+	state := systems.InputState{
+		Left:     ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA),
+		Right:    ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD),
+		Thrust:   ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW),
+		Reverse:  ebiten.IsKeyPressed(ebiten.KeyDown) || ebiten.IsKeyPressed(ebiten.KeyS),
+		Fire:     inpututil.IsKeyJustPressed(ebiten.KeySpace),
+		FireHeld: ebiten.IsKeyPressed(ebiten.KeySpace),
+		Hyperspace: inpututil.IsKeyJustPressed(ebiten.KeyShiftLeft) ||
+			inpututil.IsKeyJustPressed(ebiten.KeyShiftRight),
+		AnyPressed: len(inpututil.AppendPressedKeys(nil)) > 0 ||
+			len(inpututil.AppendJustPressedTouchIDs(nil)) > 0 ||
+			inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+	}
+
+	// The top face button on a gamepad (Y or triangle) also jumps
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightTop) {
+			state.Hyperspace = true
+		}
+	}
+
+	// Process multitouch inputs
+	justPressed := make(map[ebiten.TouchID]bool)
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		justPressed[id] = true
+	}
+	touchIDs := ebiten.TouchIDs()
+	for _, id := range touchIDs {
+		x, y := ebiten.TouchPosition(id)
+		state.Pointers = append(state.Pointers, systems.Pointer{X: x, Y: y, JustPressed: justPressed[id]})
+	}
+
+	// Handle mouse input for desktop testing
+	if len(touchIDs) == 0 && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		state.Pointers = append(state.Pointers, systems.Pointer{
+			X:           x,
+			Y:           y,
+			JustPressed: inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+		})
+	}
+
+	return state
+}
@@ -0,0 +1,322 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/geom"
+	"github.com/bobbyhiddn/ecs-asteroids/highscore"
+	"github.com/bobbyhiddn/ecs-asteroids/render"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+type RenderSystem struct {
+	world       *ecs.World
+	screen      *ebiten.Image
+	gameScreen  *game.Screen
+	highScores  *highscore.HighScores
+	positions   *ecs.Store[components.Position]
+	renderables *ecs.Store[components.Renderable]
+	rotations   *ecs.Store[components.Rotation]
+	players     *ecs.Store[components.Player]
+	explosions  *ecs.Store[components.Explosion]
+	colliders   *ecs.Store[components.Collider]
+	previous    *ecs.Store[components.PreviousTransform]
+	hyperspace  *ecs.Store[components.Hyperspace]
+	powerUps    *ecs.Store[components.PowerUp]
+	effects     *ecs.Store[components.Effects]
+	drawable    *ecs.Query
+
+	extraLifeUntil float64 // Simulation time the extra life flash ends
+}
+
+// powerUpLabels names the timed power-ups in the HUD.
+var powerUpLabels = map[components.PowerUpKind]string{
+	components.PowerUpShield:     "Shield",
+	components.PowerUpTripleShot: "Triple shot",
+	components.PowerUpRapidFire:  "Rapid fire",
+	components.PowerUpPiercing:   "Piercing",
+}
+
+// extraLifeFlash is how long the HUD flashes after an extra life is earned.
+const extraLifeFlash = 2.0
+
+// maxInterpolationDistance is the furthest an entity can move in one step
+// and still be interpolated. Anything further (screen wrap, respawn) snaps.
+const maxInterpolationDistance = 100.0
+
+func NewRenderSystem(world *ecs.World, screen *ebiten.Image) *RenderSystem {
+	s := &RenderSystem{
+		world:       world,
+		screen:      screen,
+		gameScreen:  game.ScreenOf(world),
+		highScores:  highscore.GetInstance(),
+		positions:   ecs.Register[components.Position](world),
+		renderables: ecs.Register[components.Renderable](world),
+		rotations:   ecs.Register[components.Rotation](world),
+		players:     ecs.Register[components.Player](world),
+		explosions:  ecs.Register[components.Explosion](world),
+		colliders:   ecs.Register[components.Collider](world),
+		previous:    ecs.Register[components.PreviousTransform](world),
+		hyperspace:  ecs.Register[components.Hyperspace](world),
+		powerUps:    ecs.Register[components.PowerUp](world),
+		effects:     ecs.Register[components.Effects](world),
+	}
+	s.drawable = ecs.NewQuery(s.renderables, s.positions)
+
+	ecs.Subscribe(world, func(e events.ExtraLife) {
+		s.extraLifeUntil = world.Clock().Now() + extraLifeFlash
+	})
+	return s
+}
+
+// SetScreen sets the image the next render pass draws onto.
+func (s *RenderSystem) SetScreen(screen *ebiten.Image) {
+	s.screen = screen
+}
+
+func (s *RenderSystem) Update(dt float64) {
+	if s.screen != nil {
+		s.Draw(s.screen)
+	}
+}
+
+func (s *RenderSystem) drawEntity(screen *ebiten.Image, id ecs.EntityID, renderable components.Renderable, x, y, rotation float64) {
+	switch renderable.Type {
+	case components.RenderableTypeShip:
+		isThrusting := false
+		if player, ok := s.players.Get(id); ok {
+			isThrusting = player.IsThrusting
+		}
+		render.DrawShip(screen, x, y, rotation, s.outline(id), isThrusting)
+		if effects, ok := s.effects.Get(id); ok && effects.Has(components.PowerUpShield) {
+			collider, _ := s.colliders.Get(id)
+			render.DrawShield(screen, x, y, collider.Radius+4)
+		}
+	case components.RenderableTypeBullet:
+		render.DrawBullet(screen, x, y)
+	case components.RenderableTypeAsteroid:
+		render.DrawAsteroid(screen, x, y, rotation, s.outline(id))
+	case components.RenderableTypePowerUp:
+		if powerUp, ok := s.powerUps.Get(id); ok {
+			render.DrawPowerUp(screen, x, y, rotation, powerUp.Kind)
+		}
+	case components.RenderableTypeSaucer:
+		render.DrawSaucer(screen, x, y, s.outline(id))
+	case components.RenderableTypeExplosion:
+		if explosion, ok := s.explosions.Get(id); ok {
+			render.DrawExplosion(screen, x, y, explosion)
+		}
+	}
+}
+
+// ghostOffsets returns where to draw an entity relative to its position:
+// always in place, plus a screen width or height away for every edge its
+// collider crosses.
+func (s *RenderSystem) ghostOffsets(id ecs.EntityID, pos components.Position) []geom.Point {
+	collider, ok := s.colliders.Get(id)
+	if !ok {
+		return []geom.Point{{}}
+	}
+
+	width := float64(s.gameScreen.Width())
+	height := float64(s.gameScreen.Height())
+	xs := []float64{0}
+	if pos.X-collider.Radius < 0 {
+		xs = append(xs, width)
+	}
+	if pos.X+collider.Radius > width {
+		xs = append(xs, -width)
+	}
+	ys := []float64{0}
+	if pos.Y-collider.Radius < 0 {
+		ys = append(ys, height)
+	}
+	if pos.Y+collider.Radius > height {
+		ys = append(ys, -height)
+	}
+
+	offsets := make([]geom.Point, 0, len(xs)*len(ys))
+	for _, y := range ys {
+		for _, x := range xs {
+			offsets = append(offsets, geom.Point{X: x, Y: y})
+		}
+	}
+	return offsets
+}
+
+// outline returns the polygon an entity collides with, so it is drawn
+// exactly as it collides.
+func (s *RenderSystem) outline(id ecs.EntityID) geom.Polygon {
+	collider, _ := s.colliders.Get(id)
+	return collider.Polygon
+}
+
+func drawDottedCircle(screen *ebiten.Image, x, y, radius float64, c color.Color) {
+	numSegments := 16 // Reduced for larger dots
+	for i := 0; i < numSegments; i++ {
+		angle := float64(i) * 2 * math.Pi / float64(numSegments)
+		nextAngle := float64(i+1) * 2 * math.Pi / float64(numSegments)
+
+		// Only draw every other segment for dotted effect
+		if i%2 == 0 {
+			x1 := x + radius*math.Cos(angle)
+			y1 := y + radius*math.Sin(angle)
+			x2 := x + radius*math.Cos(nextAngle)
+			y2 := y + radius*math.Sin(nextAngle)
+			ebitenutil.DrawLine(screen, x1, y1, x2, y2, c)
+		}
+	}
+}
+
+func (s *RenderSystem) Draw(screen *ebiten.Image) {
+	// Draw high score at the top center first
+	if scores := s.highScores.GetTopScores(); len(scores) > 0 {
+		highScoreText := fmt.Sprintf("HIGH SCORE: %d", scores[0].Value)
+		render.DrawCenteredScaledText(screen, highScoreText, 20, 2.0, color.White, render.DefaultFace)
+	}
+
+	// Draw all renderable entities
+	alpha := s.world.Clock().Alpha()
+	s.drawable.Each(func(id ecs.EntityID) {
+		renderable, _ := s.renderables.Get(id)
+		if !renderable.Visible {
+			return
+		}
+
+		position, _ := s.positions.Get(id)
+
+		rotation := float64(0)
+		if r, ok := s.rotations.Get(id); ok {
+			rotation = r.Angle
+		}
+
+		// Blend from the previous step towards the current one, the short
+		// way round when the entity wrapped across an edge
+		if prev, ok := s.previous.Get(id); ok {
+			dx, dy := s.gameScreen.WrapDelta(position.X-prev.X, position.Y-prev.Y)
+			if math.Abs(dx) < maxInterpolationDistance && math.Abs(dy) < maxInterpolationDistance {
+				position.X = prev.X + dx*alpha
+				position.Y = prev.Y + dy*alpha
+				rotation = prev.Angle + (rotation-prev.Angle)*alpha
+			}
+		}
+
+		// Entities straddling an edge, including ones interpolated past it,
+		// are also drawn poking in from the opposite side, where they can
+		// already be hit
+		for _, offset := range s.ghostOffsets(id, position) {
+			s.drawEntity(screen, id, renderable, position.X+offset.X, position.Y+offset.Y, rotation)
+		}
+	})
+
+	// Draw UI
+	s.players.Each(func(id ecs.EntityID, p components.Player) {
+		// Draw score
+		render.DrawScaledText(screen, fmt.Sprintf("Score: %d", p.Score), 10, 25, 1.75, color.White, render.DefaultFace)
+
+		// Draw lives as ship icons, blinking with a banner for a while
+		// after an extra life
+		now := s.world.Clock().Now()
+		flashing := now < s.extraLifeUntil
+		blink := flashing && int(now*8)%2 == 0
+		render.DrawScaledText(screen, "Lives:", 10, 60, 1.5, color.White, render.DefaultFace)
+		if !blink {
+			for i := 0; i < p.Lives; i++ {
+				render.DrawLifeShip(screen, float64(89+i*35), 73)
+			}
+		}
+		if flashing {
+			render.DrawCenteredScaledText(screen, "EXTRA LIFE", 60, 2.0, color.RGBA{255, 220, 0, 255}, render.DefaultFace)
+		}
+
+		// Draw the wave reached
+		render.DrawScaledText(screen, fmt.Sprintf("Wave: %d", p.Wave), 10, 105, 1.5, color.White, render.DefaultFace)
+
+		// Show whether hyperspace is ready, dimmed with the time left while
+		// it recharges
+		if jump, ok := s.hyperspace.Get(id); ok {
+			switch {
+			case jump.ReturnAt > 0:
+				render.DrawScaledText(screen, "Hyperspace: jumping", 10, 135, 1.25, color.Gray{Y: 128}, render.DefaultFace)
+			case now < jump.ReadyAt:
+				hyperspaceText := fmt.Sprintf("Hyperspace: %.1fs", jump.ReadyAt-now)
+				render.DrawScaledText(screen, hyperspaceText, 10, 135, 1.25, color.Gray{Y: 128}, render.DefaultFace)
+			default:
+				render.DrawScaledText(screen, "Hyperspace: ready", 10, 135, 1.25, color.White, render.DefaultFace)
+			}
+		}
+
+		// Count down each power-up still working
+		if effects, ok := s.effects.Get(id); ok {
+			for i, effect := range effects.Active {
+				effectText := fmt.Sprintf("%s: %.1fs", powerUpLabels[effect.Kind], effect.Remaining)
+				render.DrawScaledText(screen, effectText, 10, 160+i*22, 1.25, color.RGBA{100, 200, 255, 255}, render.DefaultFace)
+			}
+		}
+
+		// If game is over, draw high scores
+		if p.IsGameOver {
+			s.drawGameOver(screen, p.Score)
+		}
+
+		// Draw fire button (red dotted circle)
+		drawDottedCircle(screen, 100, float64(s.gameScreen.Height()-100), 80, color.RGBA{255, 0, 0, 255})
+	})
+}
+
+func (s *RenderSystem) drawGameOver(screen *ebiten.Image, currentScore int) {
+	centerX := s.gameScreen.CenterX()
+	startY := s.gameScreen.CenterY() - 100
+
+	// Draw Game Over text
+	gameOverText := "GAME OVER"
+	bound := text.BoundString(basicfont.Face7x13, gameOverText)
+	x := int(centerX) - bound.Dx()/2
+	y := int(startY)
+	text.Draw(screen, gameOverText, basicfont.Face7x13, x, y, color.White)
+
+	// Draw current score
+	currentScoreText := fmt.Sprintf("Your Score: %d", currentScore)
+	bound = text.BoundString(basicfont.Face7x13, currentScoreText)
+	x = int(centerX) - bound.Dx()/2
+	y = int(startY) + 30
+	text.Draw(screen, currentScoreText, basicfont.Face7x13, x, y, color.White)
+
+	// Draw high scores
+	highScoresText := "HIGH SCORES"
+	bound = text.BoundString(basicfont.Face7x13, highScoresText)
+	x = int(centerX) - bound.Dx()/2
+	y = int(startY) + 60
+	text.Draw(screen, highScoresText, basicfont.Face7x13, x, y, color.White)
+
+	topScores := s.highScores.GetTopScores()
+	for i, score := range topScores {
+		if i >= 5 { // Show only top 5 scores
+			break
+		}
+		scoreText := fmt.Sprintf("%d. %d pts", i+1, score.Value)
+		if score.Wave > 0 {
+			scoreText += fmt.Sprintf(" (wave %d)", score.Wave)
+		}
+		bound = text.BoundString(basicfont.Face7x13, scoreText)
+		x = int(centerX) - bound.Dx()/2
+		y = int(startY) + 90 + i*20
+		text.Draw(screen, scoreText, basicfont.Face7x13, x, y, color.White)
+	}
+
+	// Draw restart instruction
+	restartText := "Press SPACE to restart"
+	bound = text.BoundString(basicfont.Face7x13, restartText)
+	x = int(centerX) - bound.Dx()/2
+	y = int(startY) + 200
+	text.Draw(screen, restartText, basicfont.Face7x13, x, y, color.White)
+}
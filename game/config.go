@@ -0,0 +1,85 @@
+package game
+
+import "github.com/bobbyhiddn/ecs-asteroids/components"
+
+// Config holds gameplay tuning shared between systems. It lives in the world
+// as a resource so a mode or a test can swap in different values.
+type Config struct {
+	Waves      WaveCurve      // How each wave's hazards grow with the wave number
+	Saucers    SaucerSchedule // When saucers turn up
+	PowerUps   PowerUpTable   // What shot asteroids drop and how long it lasts
+	ExtraLives ExtraLifeRule  // When scoring earns another life, set by the ruleset
+	Flight     string         // Name of the ship's flight model in FlightPresets, set by the ruleset
+}
+
+// ExtraLifeRule awards a life for every Every points scored, starting at
+// First, while the player has fewer than MaxLives.
+type ExtraLifeRule struct {
+	First    int // Score of the first extra life, Every if zero
+	Every    int // Points between extra lives after that, 0 for none at all
+	MaxLives int // Most lives a player can hold, 0 for no cap
+}
+
+// Earned returns how many extra lives going from score before to after
+// earns.
+func (r ExtraLifeRule) Earned(before, after int) int {
+	if r.Every <= 0 {
+		return 0
+	}
+	first := r.First
+	if first <= 0 {
+		first = r.Every
+	}
+	reached := func(score int) int {
+		if score < first {
+			return 0
+		}
+		return (score-first)/r.Every + 1
+	}
+	return reached(after) - reached(before)
+}
+
+// DefaultConfig returns the standard game settings, playing the classic
+// ruleset.
+func DefaultConfig() *Config {
+	config := &Config{
+		Waves: WaveCurve{
+			LargeAsteroids: 4,
+			LargePerWave:   1,
+			MaxLarge:       11,
+			MediumFromWave: 3,
+			MediumPerWave:  1,
+			MaxMedium:      6,
+			MinSpeed:       50.0,
+			MaxSpeed:       100.0,
+			SpeedPerWave:   8.0,
+			SpeedLimit:     200.0,
+			SafeDistance:   200.0,
+			Delay:          3.0,
+		},
+		Saucers: SaucerSchedule{
+			Interval:        20.0,
+			IntervalPerWave: 1.5,
+			MinInterval:     8.0,
+			SmallFromWave:   2,
+			SmallChance:     0.2,
+			SmallPerWave:    0.1,
+			MaxSmallChance:  0.8,
+		},
+		PowerUps: PowerUpTable{
+			DropChance:        0.08,
+			Speed:             40.0,
+			RapidFireInterval: 0.12,
+			TripleShotSpread:  0.2,
+			Drops: []PowerUpDrop{
+				{Kind: components.PowerUpShield, Weight: 3, Duration: 8},
+				{Kind: components.PowerUpTripleShot, Weight: 3, Duration: 10},
+				{Kind: components.PowerUpRapidFire, Weight: 3, Duration: 10},
+				{Kind: components.PowerUpPiercing, Weight: 2, Duration: 8},
+				{Kind: components.PowerUpExtraLife, Weight: 1},
+			},
+		},
+	}
+	config.UseRuleset("classic")
+	return config
+}
@@ -3,11 +3,10 @@ package game
 import (
 	"fmt"
 	"math"
-	"math/rand"
-	"time"
 
 	"github.com/bobbyhiddn/ecs-asteroids/components"
 	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/geom"
 )
 
 const (
@@ -15,114 +14,112 @@ const (
 	screenHeight = 600
 )
 
+// AsteroidPrefabs maps asteroid sizes (0 = small, 1 = medium, 2 = large) to
+// their prefab names.
+var AsteroidPrefabs = []string{"asteroid_small", "asteroid_medium", "asteroid_large"}
+
 func CreatePlayerShip(world *ecs.World, x, y float64) ecs.EntityID {
-	id := world.CreateEntity()
-	fmt.Printf("Creating player ship with ID %d at (%f, %f)\n", id, x, y)
-
-	world.AddComponent(id, components.Position{X: x, Y: y})
-	world.AddComponent(id, components.Velocity{MaxSpeed: 400})
-	world.AddComponent(id, components.Rotation{})
-	world.AddComponent(id, components.Input{})
-	world.AddComponent(id, components.Player{Lives: 3})
-	world.AddComponent(id, components.Renderable{Type: components.RenderableTypeShip, Visible: true})
-	world.AddComponent(id, components.Collider{Radius: 15})
-	world.AddComponent(id, components.Invulnerable{Duration: 3.0, Timer: 3.0})
+	id := mustInstantiate(world, "ship", playerShip(world, x, y)...)
+	fmt.Printf("Creating player ship with ID %v at (%f, %f)\n", id, x, y)
 
 	return id
 }
 
-func CreateBullet(world *ecs.World, x, y, angle float64, shooterID ecs.EntityID) ecs.EntityID {
-	id := world.CreateEntity()
+// ResetPlayerShip rebuilds an existing player ship at (x, y) for a new game,
+// which also resets its lives and score.
+func ResetPlayerShip(world *ecs.World, id ecs.EntityID, x, y float64) error {
+	return ApplyPrefab(world, id, "ship", playerShip(world, x, y)...)
+}
 
-	speed := 500.0
-	world.AddComponent(id, components.Position{X: x, Y: y})
-	world.AddComponent(id, components.Velocity{
-		DX:       math.Cos(angle) * speed,
-		DY:       math.Sin(angle) * speed,
-		MaxSpeed: speed,
-	})
-	world.AddComponent(id, components.Renderable{
-		Type:    components.RenderableTypeBullet,
-		Scale:   1.0,
-		Visible: true,
-	})
-	world.AddComponent(id, components.Lifetime{
-		Created:  time.Now(),
-		Duration: 750 * time.Millisecond,
-	})
-	world.AddComponent(id, components.Collider{
-		Radius: 2,
-		Type:   components.ColliderTypeBullet,
-	})
-	world.AddComponent(id, components.Bullet{
-		ShooterID: int(shooterID),
-	})
+// playerShip returns the components a player ship gets on top of its
+// prefab.
+func playerShip(world *ecs.World, x, y float64) []any {
+	return []any{components.Position{X: x, Y: y}, FlightModelOf(world)}
+}
 
-	return id
+// CreateBulletFromPrefab fires the named bullet prefab from (x, y) at its
+// top speed along angle.
+func CreateBulletFromPrefab(world *ecs.World, prefab string, x, y, angle float64, shooterID ecs.EntityID) ecs.EntityID {
+	vel, _ := PrefabComponent[components.Velocity](prefab)
+	speed := vel.MaxSpeed
+	vel.DX = math.Cos(angle) * speed
+	vel.DY = math.Sin(angle) * speed
+
+	lifetime, _ := PrefabComponent[components.Lifetime](prefab)
+	lifetime.Created = world.Clock().Now()
+
+	return mustInstantiate(world, prefab,
+		components.Position{X: x, Y: y},
+		vel,
+		lifetime,
+		components.Bullet{ShooterID: shooterID},
+	)
 }
 
 func CreateAsteroid(world *ecs.World, size int) ecs.EntityID {
-	id := world.CreateEntity()
-
-	var scale float64
-	var radius float64
-	var maxSpeed float64
-	switch size {
-	case 0: // Small
-		scale = 0.5
-		radius = 10
-		maxSpeed = 300
-	case 1: // Medium
-		scale = 1.0
-		radius = 20
-		maxSpeed = 200
-	case 2: // Large
-		scale = 2.0
-		radius = 40
-		maxSpeed = 100
-	}
-
-	world.AddComponent(id, components.Position{X: 0, Y: 0})
-	world.AddComponent(id, components.Velocity{
-		DX:       0,
-		DY:       0,
-		MaxSpeed: maxSpeed,
-	})
-	world.AddComponent(id, components.Rotation{
-		Angle:         rand.Float64() * math.Pi * 2,
-		RotationSpeed: (rand.Float64() - 0.5) * 2,
-	})
-	world.AddComponent(id, components.Renderable{
-		Type:    components.RenderableTypeAsteroid,
-		Scale:   scale,
-		Visible: true,
-	})
-	world.AddComponent(id, components.Collider{
-		Radius: radius,
-		Type:   components.ColliderTypeAsteroid,
-	})
-	world.AddComponent(id, components.Asteroid{
-		Size: size,
+	return CreateAsteroidFromPrefab(world, AsteroidPrefabs[size])
+}
+
+// CreateAsteroidFromPrefab spawns the named asteroid prefab at the origin
+// with a random spin. Callers position it and set its velocity.
+func CreateAsteroidFromPrefab(world *ecs.World, prefab string) ecs.EntityID {
+	// The outline is built around the prefab's collider radius, which then
+	// grows to enclose the jagged vertices
+	collider, _ := PrefabComponent[components.Collider](prefab)
+	collider.Polygon = geom.AsteroidOutline(collider.Radius)
+	collider.Radius = collider.Polygon.Radius()
+
+	// Heavier asteroids are harder to push around and to spin
+	body, _ := PrefabComponent[components.RigidBody](prefab)
+	body.Mass = collider.Polygon.Area() * body.Density
+	body.Inertia = collider.Polygon.SecondMoment() * body.Density
+
+	rng := RandOf(world)
+	return mustInstantiate(world, prefab, collider, body, components.Rotation{
+		Angle:         rng.Float64() * math.Pi * 2,
+		RotationSpeed: (rng.Float64() - 0.5) * 2,
 	})
+}
 
-	return id
+// CreateSaucer sends the named saucer prefab across the screen from (x, y),
+// heading right if dir is positive and left otherwise.
+func CreateSaucer(world *ecs.World, prefab string, x, y, dir float64) ecs.EntityID {
+	vel, _ := PrefabComponent[components.Velocity](prefab)
+	vel.DX = math.Copysign(vel.MaxSpeed, dir)
+	vel.DY = 0
+
+	now := world.Clock().Now()
+	saucer, _ := PrefabComponent[components.Saucer](prefab)
+	saucer.NextShot = now + saucer.FireInterval
+	saucer.NextTurn = now + saucer.TurnInterval
+
+	return mustInstantiate(world, prefab, components.Position{X: x, Y: y}, vel, saucer)
 }
 
-func CreateExplosion(world *ecs.World, x, y float64, size float64) ecs.EntityID {
-	id := world.CreateEntity()
+// CreatePowerUp drops a pickup of the given kind at (x, y), drifting along
+// angle at speed.
+func CreatePowerUp(world *ecs.World, kind components.PowerUpKind, x, y, angle, speed float64) ecs.EntityID {
+	vel, _ := PrefabComponent[components.Velocity]("powerup")
+	vel.DX = math.Cos(angle) * speed
+	vel.DY = math.Sin(angle) * speed
+
+	lifetime, _ := PrefabComponent[components.Lifetime]("powerup")
+	lifetime.Created = world.Clock().Now()
+
+	return mustInstantiate(world, "powerup",
+		components.Position{X: x, Y: y},
+		vel,
+		lifetime,
+		components.PowerUp{Kind: kind},
+	)
+}
 
-	world.AddComponent(id, components.Position{X: x, Y: y})
-	world.AddComponent(id, components.Renderable{
-		Type:    components.RenderableTypeExplosion,
-		Scale:   1.0,
-		Visible: true,
-	})
-	world.AddComponent(id, components.Explosion{
-		Age:    0,
-		MaxAge: 0.5, // Explosion lasts 0.5 seconds
-		Radius: size,
-		Pieces: 12, // Number of particles in explosion
-	})
+func CreateExplosion(world *ecs.World, x, y float64, size float64) ecs.EntityID {
+	explosion, _ := PrefabComponent[components.Explosion]("explosion")
+	explosion.Radius = size
 
-	return id
+	return mustInstantiate(world, "explosion",
+		components.Position{X: x, Y: y},
+		explosion,
+	)
 }
@@ -0,0 +1,38 @@
+package game
+
+import (
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+)
+
+// FlightPresets are the ship handling models a ruleset can pick by name.
+var FlightPresets = map[string]components.FlightModel{
+	// Arcade handling: the ship turns at a fixed rate the moment a key is
+	// pressed and slowly drifts to a stop
+	"classic": {
+		Thrust:           250,
+		Drag:             0.4,
+		TurnAcceleration: 1000,
+		MaxTurnRate:      6,
+		TurnDamping:      1000,
+	},
+	// Heavier handling with momentum in the turns, stronger drag and a
+	// reverse thruster for braking
+	"modern": {
+		Thrust:           300,
+		ReverseThrust:    150,
+		Drag:             0.8,
+		TurnAcceleration: 20,
+		MaxTurnRate:      5,
+		TurnDamping:      8,
+	},
+}
+
+// FlightModelOf returns the flight model the world's config picks, falling
+// back to the classic one for an unknown name.
+func FlightModelOf(world *ecs.World) components.FlightModel {
+	if model, ok := FlightPresets[ConfigOf(world).Flight]; ok {
+		return model
+	}
+	return FlightPresets["classic"]
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+)
+
+// PowerUpDrop is one kind of power-up asteroids can drop. Weight is its
+// share of the drops and Duration how long its effect lasts, unused for
+// power-ups that act at once.
+type PowerUpDrop struct {
+	Kind     components.PowerUpKind
+	Weight   float64
+	Duration float64
+}
+
+// PowerUpTable sets how often shot asteroids drop power-ups, which ones,
+// and how the timed ones behave.
+type PowerUpTable struct {
+	DropChance        float64 // Chance a shot asteroid leaves a pickup
+	Speed             float64 // How fast pickups drift
+	RapidFireInterval float64 // Seconds between shots with rapid fire held
+	TripleShotSpread  float64 // Angle in radians between triple shot bullets
+	Drops             []PowerUpDrop
+}
+
+// Pick chooses a drop at random by weight.
+func (t PowerUpTable) Pick(rng *rand.Rand) (PowerUpDrop, bool) {
+	total := 0.0
+	for _, drop := range t.Drops {
+		total += drop.Weight
+	}
+	if total <= 0 {
+		return PowerUpDrop{}, false
+	}
+
+	r := rng.Float64() * total
+	for _, drop := range t.Drops {
+		r -= drop.Weight
+		if r < 0 {
+			return drop, true
+		}
+	}
+	return t.Drops[len(t.Drops)-1], true
+}
+
+// Duration returns how long the effect of kind lasts.
+func (t PowerUpTable) Duration(kind components.PowerUpKind) float64 {
+	for _, drop := range t.Drops {
+		if drop.Kind == kind {
+			return drop.Duration
+		}
+	}
+	return 0
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+)
+
+// prefabFile is the layout of prefabs.json. Component names are type names
+// from the components package, e.g. "Velocity"; fields left out of a
+// component take their zero value.
+type prefabFile map[string]struct {
+	Components map[string]json.RawMessage `json:"components"`
+}
+
+// prefab holds a prefab's component values, decoded once at startup and
+// ordered by name so instantiation is deterministic. Each entity gets a copy
+// of the values, but slices in them such as collider polygons are shared
+// and must not be modified in place.
+type prefab struct {
+	components []any
+}
+
+//go:embed prefabs.json
+var prefabData []byte
+
+var prefabs = mustParsePrefabs(prefabData)
+
+func mustParsePrefabs(data []byte) map[string]prefab {
+	var file prefabFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		panic(fmt.Sprintf("game: parsing prefabs.json: %v", err))
+	}
+
+	// A scratch world knows every component type by name
+	decoder := ecs.NewWorld()
+	components.Register(decoder)
+
+	parsed := make(map[string]prefab, len(file))
+	for name, def := range file {
+		names := make([]string, 0, len(def.Components))
+		for component := range def.Components {
+			names = append(names, component)
+		}
+		sort.Strings(names)
+
+		p := prefab{components: make([]any, 0, len(names))}
+		for _, component := range names {
+			value, err := decoder.DecodeComponent(qualifiedName(component), def.Components[component])
+			if err != nil {
+				panic(fmt.Sprintf("game: prefab %q: %v", name, err))
+			}
+			p.components = append(p.components, value)
+		}
+		parsed[name] = p
+	}
+	return parsed
+}
+
+// HasPrefab reports whether a prefab with the given name is defined.
+func HasPrefab(name string) bool {
+	_, ok := prefabs[name]
+	return ok
+}
+
+// Instantiate creates a new entity from the named prefab. Each override is a
+// component value that replaces the prefab's component of the same type, or
+// adds it if the prefab doesn't have one.
+func Instantiate(world *ecs.World, name string, overrides ...any) (ecs.EntityID, error) {
+	id := world.CreateEntity()
+	if err := ApplyPrefab(world, id, name, overrides...); err != nil {
+		world.DestroyEntity(id)
+		return ecs.NoEntity, err
+	}
+	return id, nil
+}
+
+// ApplyPrefab sets every component of the named prefab on an existing
+// entity, followed by the overrides. Components the prefab doesn't mention
+// are left alone.
+func ApplyPrefab(world *ecs.World, id ecs.EntityID, name string, overrides ...any) error {
+	prefab, ok := prefabs[name]
+	if !ok {
+		return fmt.Errorf("game: unknown prefab %q", name)
+	}
+
+	for _, value := range prefab.components {
+		if err := world.SetComponent(id, value); err != nil {
+			return fmt.Errorf("game: prefab %q: %w", name, err)
+		}
+	}
+	for _, override := range overrides {
+		if err := world.SetComponent(id, override); err != nil {
+			return fmt.Errorf("game: prefab %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// PrefabComponent returns the T component defined by the named prefab, so
+// code can start from the designer's values and adjust them.
+func PrefabComponent[T any](name string) (T, bool) {
+	for _, value := range prefabs[name].components {
+		if component, ok := value.(T); ok {
+			return component, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func qualifiedName(component string) string {
+	if strings.Contains(component, ".") {
+		return component
+	}
+	return "components." + component
+}
+
+func mustInstantiate(world *ecs.World, name string, overrides ...any) ecs.EntityID {
+	id, err := Instantiate(world, name, overrides...)
+	if err != nil {
+		// Prefabs are embedded in the binary, so this is a programming error
+		panic(err)
+	}
+	return id
+}
@@ -0,0 +1,35 @@
+package game
+
+// Ruleset is a game mode: the settings that set it apart from the others.
+type Ruleset struct {
+	ExtraLives ExtraLifeRule // When scoring earns another life
+	Flight     string        // Name of the ship's flight model in FlightPresets
+}
+
+// Rulesets are the game modes a player can pick by name.
+var Rulesets = map[string]Ruleset{
+	// The original cabinet: a life every 10,000 points and the arcade
+	// handling
+	"classic": {
+		ExtraLives: ExtraLifeRule{Every: 10000, MaxLives: 9},
+		Flight:     "classic",
+	},
+	// Heavier handling to master, paid for with an earlier first life but
+	// a tighter cap
+	"arcade": {
+		ExtraLives: ExtraLifeRule{First: 5000, Every: 15000, MaxLives: 5},
+		Flight:     "modern",
+	},
+}
+
+// UseRuleset switches the config to the named ruleset. It reports false and
+// leaves the config alone if there is no such ruleset.
+func (c *Config) UseRuleset(name string) bool {
+	ruleset, ok := Rulesets[name]
+	if !ok {
+		return false
+	}
+	c.ExtraLives = ruleset.ExtraLives
+	c.Flight = ruleset.Flight
+	return true
+}
@@ -1,5 +1,7 @@
 package game
 
+import "math"
+
 // Screen dimensions
 const (
 	DefaultWidth  = 1200
@@ -36,12 +38,12 @@ func (s *Screen) SetDimensions(w, h int) {
 	s.height = h
 }
 
-// GetActualWidth implements types.GameDimensions
+// GetActualWidth returns the current screen width
 func (s *Screen) GetActualWidth() int {
 	return s.width
 }
 
-// GetActualHeight implements types.GameDimensions
+// GetActualHeight returns the current screen height
 func (s *Screen) GetActualHeight() int {
 	return s.height
 }
@@ -81,3 +83,18 @@ func (s *Screen) WrapCoordinates(x, y float64) (float64, float64) {
 
 	return wrappedX, wrappedY
 }
+
+// WrapDelta returns the shortest offset (dx, dy) between two points on the
+// wrapping playfield, where leaving one edge means entering the opposite one.
+func (s *Screen) WrapDelta(dx, dy float64) (float64, float64) {
+	w, h := float64(s.width), float64(s.height)
+
+	// Only points more than half a screen apart are nearer the other way
+	if math.Abs(dx) >= w/2 {
+		dx -= w * math.Round(dx/w)
+	}
+	if math.Abs(dy) >= h/2 {
+		dy -= h * math.Round(dy/h)
+	}
+	return dx, dy
+}
@@ -0,0 +1,70 @@
+package game
+
+import "math"
+
+// Wave is the set of hazards that start one wave.
+type Wave struct {
+	Number   int
+	Large    int     // Large asteroids
+	Medium   int     // Medium asteroids added to the mix in later waves
+	MinSpeed float64 // Slowest an asteroid drifts
+	MaxSpeed float64 // Fastest an asteroid drifts
+}
+
+// WaveCurve is the difficulty curve: the first wave's hazards and how much
+// each later wave adds, up to a cap.
+type WaveCurve struct {
+	LargeAsteroids float64 // Large asteroids in the first wave
+	LargePerWave   float64 // Extra large asteroids each wave
+	MaxLarge       int
+	MediumFromWave int     // First wave with medium asteroids, 0 for never
+	MediumPerWave  float64 // Extra medium asteroids each wave after that
+	MaxMedium      int
+	MinSpeed       float64 // Asteroid speed range in the first wave
+	MaxSpeed       float64
+	SpeedPerWave   float64 // Added to both ends of the range each wave
+	SpeedLimit     float64 // Cap on asteroid speed however late the wave
+	SafeDistance   float64 // Asteroids start at least this far from a ship
+	Delay          float64 // Seconds between clearing a wave and the next
+}
+
+// Wave returns the hazards for wave n, counting from 1.
+func (c WaveCurve) Wave(n int) Wave {
+	later := float64(n - 1)
+
+	wave := Wave{
+		Number:   n,
+		Large:    min(int(c.LargeAsteroids+c.LargePerWave*later), c.MaxLarge),
+		MinSpeed: math.Min(c.MinSpeed+c.SpeedPerWave*later, c.SpeedLimit),
+		MaxSpeed: math.Min(c.MaxSpeed+c.SpeedPerWave*later, c.SpeedLimit),
+	}
+	if c.MediumFromWave > 0 && n >= c.MediumFromWave {
+		wave.Medium = min(int(c.MediumPerWave*float64(n-c.MediumFromWave+1)), c.MaxMedium)
+	}
+	return wave
+}
+
+// SaucerSchedule controls how often saucers appear and how likely each one
+// is to be the small, accurate kind.
+type SaucerSchedule struct {
+	Interval        float64 // Seconds from one saucer leaving to the next arriving
+	IntervalPerWave float64 // Taken off the interval each wave
+	MinInterval     float64
+	SmallFromWave   int     // First wave small saucers can appear in
+	SmallChance     float64 // Chance a saucer is small in that wave
+	SmallPerWave    float64 // Added to the chance each wave after
+	MaxSmallChance  float64
+}
+
+// IntervalAt returns the seconds between saucers during wave n.
+func (s SaucerSchedule) IntervalAt(n int) float64 {
+	return math.Max(s.Interval-s.IntervalPerWave*float64(n-1), s.MinInterval)
+}
+
+// SmallChanceAt returns the chance a saucer arriving in wave n is small.
+func (s SaucerSchedule) SmallChanceAt(n int) float64 {
+	if n < s.SmallFromWave {
+		return 0
+	}
+	return math.Min(s.SmallChance+s.SmallPerWave*float64(n-s.SmallFromWave), s.MaxSmallChance)
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+)
+
+// NewWorld creates a world with every component registered and the
+// resources the systems expect: the screen, a random source seeded with
+// seed, the simulation clock and the default config.
+func NewWorld(seed int64) *ecs.World {
+	world := ecs.NewWorld()
+	components.Register(world)
+
+	ecs.SetResource(world, NewScreen())
+	ecs.SetResource(world, rand.New(rand.NewSource(seed)))
+	ecs.SetResource(world, DefaultConfig())
+
+	return world
+}
+
+// ScreenOf returns the world's screen resource.
+func ScreenOf(world *ecs.World) *Screen {
+	return ecs.Resource[*Screen](world)
+}
+
+// RandOf returns the world's random source. All gameplay randomness should
+// come from here so a seeded run is reproducible.
+func RandOf(world *ecs.World) *rand.Rand {
+	return ecs.Resource[*rand.Rand](world)
+}
+
+// ConfigOf returns the world's gameplay config.
+func ConfigOf(world *ecs.World) *Config {
+	return ecs.Resource[*Config](world)
+}
+
+// StartGame creates the player ship in the middle of the screen and returns
+// it. The wave system fills the field with asteroids.
+func StartGame(world *ecs.World) ecs.EntityID {
+	screen := ScreenOf(world)
+	return CreatePlayerShip(world, screen.CenterX(), screen.CenterY())
+}
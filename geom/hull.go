@@ -0,0 +1,111 @@
+package geom
+
+import "math"
+
+// Hull is a polygon placed in the world. Overlap tests split it into the
+// triangle fan around Center, each of which is convex, so jagged outlines
+// collide exactly rather than by their convex envelope.
+type Hull struct {
+	Center Point
+	Points []Point
+}
+
+type triangle [3]Point
+
+func (h Hull) triangles(dst []triangle) []triangle {
+	for i, p := range h.Points {
+		q := h.Points[(i+1)%len(h.Points)]
+		dst = append(dst, triangle{h.Center, p, q})
+	}
+	return dst
+}
+
+// HullsOverlap reports whether two hulls intersect.
+func HullsOverlap(a, b Hull) bool {
+	ta := a.triangles(nil)
+	tb := b.triangles(nil)
+	for _, t1 := range ta {
+		for _, t2 := range tb {
+			if trianglesOverlap(t1, t2) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HullCircleOverlap reports whether a hull intersects the circle at c with
+// the given radius.
+func HullCircleOverlap(h Hull, c Point, radius float64) bool {
+	for _, t := range h.triangles(nil) {
+		if triangleCircleOverlap(t, c, radius) {
+			return true
+		}
+	}
+	return false
+}
+
+// trianglesOverlap applies the separating axis test using the edge normals
+// of both triangles.
+func trianglesOverlap(a, b triangle) bool {
+	return !hasSeparatingAxis(a, b) && !hasSeparatingAxis(b, a)
+}
+
+func hasSeparatingAxis(a, b triangle) bool {
+	for i := range a {
+		p, q := a[i], a[(i+1)%3]
+		axis := Point{X: q.Y - p.Y, Y: p.X - q.X}
+		minA, maxA := project(a, axis)
+		minB, maxB := project(b, axis)
+		if maxA < minB || maxB < minA {
+			return true
+		}
+	}
+	return false
+}
+
+func project(t triangle, axis Point) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, p := range t {
+		d := p.X*axis.X + p.Y*axis.Y
+		lo = math.Min(lo, d)
+		hi = math.Max(hi, d)
+	}
+	return lo, hi
+}
+
+func triangleCircleOverlap(t triangle, c Point, radius float64) bool {
+	if pointInTriangle(t, c) {
+		return true
+	}
+	for i := range t {
+		if segmentDistance(t[i], t[(i+1)%3], c) < radius {
+			return true
+		}
+	}
+	return false
+}
+
+func pointInTriangle(t triangle, p Point) bool {
+	d1 := cross(t[0], t[1], p)
+	d2 := cross(t[1], t[2], p)
+	d3 := cross(t[2], t[0], p)
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
+
+func cross(a, b, p Point) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+}
+
+// segmentDistance returns the distance from p to the segment ab.
+func segmentDistance(a, b, p Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lenSq := dx*dx + dy*dy
+	t := 0.0
+	if lenSq > 0 {
+		t = math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/lenSq))
+	}
+	return math.Hypot(a.X+t*dx-p.X, a.Y+t*dy-p.Y)
+}
@@ -0,0 +1,76 @@
+// Package geom holds the polygon outlines shared by rendering and collision
+// so that what is drawn is exactly what can be hit.
+package geom
+
+import "math"
+
+// Point is a 2D point or offset.
+type Point struct {
+	X, Y float64
+}
+
+// Polygon is a closed outline in an entity's local space, centred on the
+// entity's position. Outlines must be star-shaped around the origin: a
+// straight line from the origin reaches every vertex without leaving the
+// polygon. Jagged asteroid outlines and the ship's triangle both are.
+type Polygon []Point
+
+// Radius returns the distance from the origin to the furthest vertex, which
+// is the smallest circle enclosing the polygon.
+func (p Polygon) Radius() float64 {
+	r := 0.0
+	for _, v := range p {
+		r = math.Max(r, math.Hypot(v.X, v.Y))
+	}
+	return r
+}
+
+// Place rotates the polygon by angle and moves it to (x, y).
+func (p Polygon) Place(x, y, angle float64) Hull {
+	sin, cos := math.Sincos(angle)
+	points := make([]Point, len(p))
+	for i, v := range p {
+		points[i] = Point{
+			X: v.X*cos - v.Y*sin + x,
+			Y: v.X*sin + v.Y*cos + y,
+		}
+	}
+	return Hull{Center: Point{X: x, Y: y}, Points: points}
+}
+
+// AsteroidOutline returns the jagged 12-point asteroid outline around a
+// circle of the given radius. Vertices reach out to 1.2 times the radius.
+func AsteroidOutline(radius float64) Polygon {
+	const numPoints = 12
+	outline := make(Polygon, numPoints)
+	for i := range outline {
+		angle := float64(i) * 2 * math.Pi / numPoints
+		// Vary the radius of each vertex to make the outline rough
+		r := radius * (0.8 + 0.4*math.Sin(float64(i)*3))
+		outline[i] = Point{X: r * math.Cos(angle), Y: r * math.Sin(angle)}
+	}
+	return outline
+}
+
+// Area returns the area enclosed by the polygon.
+func (p Polygon) Area() float64 {
+	area := 0.0
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return math.Abs(area) / 2
+}
+
+// SecondMoment returns the polygon's polar second moment of area about the
+// origin. Multiplied by a density it gives the moment of inertia of a plate
+// with that outline spinning about its position.
+func (p Polygon) SecondMoment() float64 {
+	sum := 0.0
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		cross := a.X*b.Y - b.X*a.Y
+		sum += cross * (a.X*a.X + a.X*b.X + b.X*b.X + a.Y*a.Y + a.Y*b.Y + b.Y*b.Y)
+	}
+	return math.Abs(sum) / 12
+}
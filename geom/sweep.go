@@ -0,0 +1,102 @@
+package geom
+
+import "math"
+
+// SweepCircle moves a circle of radius r from one point to another and
+// returns the earliest fraction of the move, from 0 to 1, at which it
+// touches the circle at c with radius cr.
+func SweepCircle(from, to Point, r float64, c Point, cr float64) (float64, bool) {
+	return rayCircle(from, sub(to, from), c, r+cr)
+}
+
+// SweepCircleHull moves a circle of radius r from one point to another and
+// returns the earliest fraction of the move, from 0 to 1, at which it
+// touches the hull.
+func SweepCircleHull(from, to Point, r float64, h Hull) (float64, bool) {
+	if HullCircleOverlap(h, from, r) {
+		return 0, true
+	}
+
+	// Starting outside, the first contact is with the outline, so test the
+	// path against each edge grown by r
+	d := sub(to, from)
+	best, hit := math.Inf(1), false
+	for i, a := range h.Points {
+		b := h.Points[(i+1)%len(h.Points)]
+		if t, ok := rayCapsule(from, d, a, b, r); ok && t < best {
+			best, hit = t, true
+		}
+	}
+	return best, hit
+}
+
+// rayCircle returns the first t in [0, 1] where from + t*d is within r of c.
+func rayCircle(from, d, c Point, r float64) (float64, bool) {
+	m := sub(from, c)
+	cc := dot(m, m) - r*r
+	if cc <= 0 {
+		return 0, true
+	}
+	a := dot(d, d)
+	if a == 0 {
+		return 0, false
+	}
+	b := dot(m, d)
+	disc := b*b - a*cc
+	if b > 0 || disc < 0 {
+		// Moving away, or the line misses
+		return 0, false
+	}
+	t := (-b - math.Sqrt(disc)) / a
+	return t, t <= 1
+}
+
+// rayCapsule returns the first t in [0, 1] where from + t*d is within r of
+// the segment ab.
+func rayCapsule(from, d, a, b Point, r float64) (float64, bool) {
+	best, hit := math.Inf(1), false
+	for _, c := range []Point{a, b} {
+		if t, ok := rayCircle(from, d, c, r); ok && t < best {
+			best, hit = t, true
+		}
+	}
+
+	// The flat sides of the capsule
+	e := sub(b, a)
+	length := math.Hypot(e.X, e.Y)
+	if length == 0 {
+		return best, hit
+	}
+	n := Point{X: -e.Y / length * r, Y: e.X / length * r}
+	for _, side := range []Point{n, {X: -n.X, Y: -n.Y}} {
+		if t, ok := raySegment(from, d, add(a, side), add(b, side)); ok && t < best {
+			best, hit = t, true
+		}
+	}
+	return best, hit
+}
+
+// raySegment returns the t in [0, 1] where from + t*d crosses segment ab.
+func raySegment(from, d, a, b Point) (float64, bool) {
+	e := sub(b, a)
+	denom := d.X*e.Y - d.Y*e.X
+	if denom == 0 {
+		return 0, false
+	}
+	w := sub(a, from)
+	t := (w.X*e.Y - w.Y*e.X) / denom
+	u := (w.X*d.Y - w.Y*d.X) / denom
+	return t, t >= 0 && t <= 1 && u >= 0 && u <= 1
+}
+
+func add(a, b Point) Point {
+	return Point{X: a.X + b.X, Y: a.Y + b.Y}
+}
+
+func sub(a, b Point) Point {
+	return Point{X: a.X - b.X, Y: a.Y - b.Y}
+}
+
+func dot(a, b Point) float64 {
+	return a.X*b.X + a.Y*b.Y
+}
@@ -16,6 +16,7 @@ import (
 
 type Score struct {
 	Value     int       `json:"value"`
+	Wave      int       `json:"wave,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -47,13 +48,15 @@ func GetInstance() *HighScores {
 	return instance
 }
 
-// AddScore adds a new score and maintains only the top scores
-func (hs *HighScores) AddScore(value int) {
+// AddScore adds a score reached on the given wave and maintains only the
+// top scores
+func (hs *HighScores) AddScore(value, wave int) {
 	hs.mu.Lock()
 	defer hs.mu.Unlock()
 
 	score := Score{
 		Value:     value,
+		Wave:      wave,
 		Timestamp: time.Now(),
 	}
 
@@ -14,6 +14,7 @@ import (
 
 type Score struct {
 	Value     int       `json:"value"`
+	Wave      int       `json:"wave,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -32,12 +33,13 @@ func GetInstance() *HighScores {
 	return instance
 }
 
-func (hs *HighScores) AddScore(value int) {
+func (hs *HighScores) AddScore(value, wave int) {
 	hs.mu.Lock()
 	defer hs.mu.Unlock()
 
 	hs.Scores = append(hs.Scores, Score{
 		Value:     value,
+		Wave:      wave,
 		Timestamp: time.Now(),
 	})
 
@@ -1,112 +1,161 @@
 package main
 
 import (
-	"fmt"
 	"image/color"
 	"log"
-	"math/rand"
 	"time"
 
-	"github.com/bobbyhiddn/ecs-asteroids/components"
 	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/frontend"
 	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/highscore"
+	"github.com/bobbyhiddn/ecs-asteroids/render"
+	"github.com/bobbyhiddn/ecs-asteroids/savegame"
 	"github.com/bobbyhiddn/ecs-asteroids/systems"
 	"github.com/hajimehoshi/ebiten/v2"
-)
-
-const (
-	// Game settings
-	initialAsteroids      = 4
-	asteroidSpawnInterval = 5 * time.Second
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 type Game struct {
-	screen             *game.Screen
-	world              *ecs.World
-	inputSystem        *systems.InputSystem
-	playerSystem       *systems.PlayerSystem
-	movementSystem     *systems.MovementSystem
-	collisionSystem    *systems.CollisionSystem
-	renderSystem       *systems.RenderSystem
-	asteroidSpawner    *systems.AsteroidSpawnerSystem
-	explosionSystem    *systems.ExplosionSystem
-	invulnerableSystem *systems.InvulnerableSystem
-	scoreSystem        *systems.ScoreSystem
+	screen       *game.Screen
+	world        *ecs.World
+	renderSystem *frontend.RenderSystem
+	focused      bool
+	lastUpdate   time.Time // When the world last advanced, zero while paused
+	savedGame    []byte    // Snapshot offered for resuming, nil once answered
 }
 
 func NewGame() *Game {
+	world := game.NewWorld(time.Now().UnixNano())
 	g := &Game{
-		screen: game.NewScreen(),
-		world:  ecs.NewWorld(),
+		screen: game.ScreenOf(world),
+		world:  world,
 	}
 
+	// The high score table lives on disk, so only the real game gets one
+	ecs.SetResource(g.world, highscore.GetInstance())
+
 	// Create systems
-	g.inputSystem = systems.NewInputSystem(g.world)
-	g.playerSystem = systems.NewPlayerSystem(g.world)
-	g.movementSystem = systems.NewMovementSystem(g.world)
-	g.collisionSystem = systems.NewCollisionSystem(g.world)
-	g.renderSystem = systems.NewRenderSystem(g.world, ebiten.NewImage(g.screen.Width(), g.screen.Height()))
-	g.asteroidSpawner = systems.NewAsteroidSpawnerSystem(g.world)
-	g.explosionSystem = systems.NewExplosionSystem(g.world)
-	g.invulnerableSystem = systems.NewInvulnerableSystem(g.world)
-	g.scoreSystem = systems.NewScoreSystem(g.world)
-
-	g.world.AddSystem(g.inputSystem)
-	g.world.AddSystem(g.playerSystem)
-	g.world.AddSystem(g.movementSystem)
-	g.world.AddSystem(g.invulnerableSystem)
-	g.world.AddSystem(g.collisionSystem)
-	g.world.AddSystem(g.renderSystem)
-	g.world.AddSystem(g.asteroidSpawner)
-	g.world.AddSystem(g.explosionSystem)
-	g.world.AddSystem(g.scoreSystem)
-
-	// Create player ship
-	game.CreatePlayerShip(g.world, g.screen.CenterX(), g.screen.CenterY())
-
-	// Create initial asteroids
-	for i := 0; i < initialAsteroids; i++ {
-		game.CreateAsteroid(g.world, rand.Intn(3))
+	g.renderSystem = frontend.NewRenderSystem(g.world, ebiten.NewImage(g.screen.Width(), g.screen.Height()))
+
+	systems.AddGameSystems(g.world, frontend.EbitenInput{})
+	g.world.AddSystem("render", ecs.PhaseRender, g.renderSystem)
+
+	ecs.Subscribe(g.world, func(e events.GameOver) {
+		// A finished run can't be resumed
+		savegame.Clear()
+	})
+
+	game.StartGame(g.world)
+
+	// Offer to pick up where the last session left off
+	if data, ok := savegame.Load(); ok {
+		g.savedGame = data
 	}
 
 	return g
 }
 
 func (g *Game) Update() error {
-	dt := 1.0 / 60.0
-
-	// Always update input system to check for restart
-	g.inputSystem.Update(dt)
-
-	// Get player state
-	players := g.world.Components["components.Player"]
-	for _, playerInterface := range players {
-		player := playerInterface.(components.Player)
-		if player.IsGameOver {
-			fmt.Printf("Game is over, waiting for restart input...\n")
-			// Only update input system during game over
-			return nil
-		}
+	if g.savedGame != nil {
+		g.updateResumePrompt()
+		g.lastUpdate = time.Time{}
+		return nil
 	}
 
-	// Update all systems when game is active
-	g.playerSystem.Update(dt)
-	g.movementSystem.Update(dt)
-	g.invulnerableSystem.Update(dt)
-	g.collisionSystem.Update(dt)
-	g.asteroidSpawner.Update(dt)
-	g.explosionSystem.Update(dt)
-	g.scoreSystem.Update(dt)
+	// Losing focus is how a backgrounded tab or app shows up; save then,
+	// since the process may be killed without further notice
+	focused := ebiten.IsFocused()
+	if g.focused && !focused {
+		g.autosave()
+	}
+	g.focused = focused
+	if !focused {
+		g.lastUpdate = time.Time{}
+		return nil
+	}
 
+	// Ebiten calls Update once per displayed frame; the world turns the
+	// real time since the last one into however many fixed simulation steps
+	// are due, and what's left over is how far to interpolate when drawing.
+	// Time spent paused doesn't count.
+	now := time.Now()
+	var elapsed float64
+	if !g.lastUpdate.IsZero() {
+		elapsed = now.Sub(g.lastUpdate).Seconds()
+	}
+	g.lastUpdate = now
+	g.world.Advance(elapsed)
 	return nil
 }
 
+func (g *Game) autosave() {
+	// Nothing worth resuming once the game is over
+	if !g.world.PhaseEnabled(ecs.PhaseSimulation) {
+		return
+	}
+
+	data, err := g.world.SaveBinary()
+	if err != nil {
+		log.Printf("autosave failed: %v", err)
+		return
+	}
+	if err := savegame.Save(data); err != nil {
+		log.Printf("autosave failed: %v", err)
+	}
+}
+
+func (g *Game) updateResumePrompt() {
+	resume, fresh := false, false
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		resume = true
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		fresh = true
+	}
+
+	// Tapping the top half resumes, the bottom half starts over
+	for _, touchID := range inpututil.AppendJustPressedTouchIDs(nil) {
+		_, y := ebiten.TouchPosition(touchID)
+		resume = resume || y < g.screen.Height()/2
+		fresh = fresh || y >= g.screen.Height()/2
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		_, y := ebiten.CursorPosition()
+		resume = resume || y < g.screen.Height()/2
+		fresh = fresh || y >= g.screen.Height()/2
+	}
+
+	switch {
+	case resume:
+		if err := g.world.Load(g.savedGame); err != nil {
+			log.Printf("could not resume saved game: %v", err)
+		}
+	case fresh:
+		savegame.Clear()
+	default:
+		return
+	}
+	g.savedGame = nil
+}
+
 func (g *Game) Draw(screen *ebiten.Image) {
 	// Clear the screen
 	screen.Fill(color.Black)
 
+	if g.savedGame != nil {
+		centerY := int(g.screen.CenterY())
+		render.DrawCenteredScaledText(screen, "RESUME SAVED GAME?", centerY-60, 3.0, color.White, render.DefaultFace)
+		render.DrawCenteredScaledText(screen, "Press R or tap the top half to resume", centerY, 1.5, color.White, render.DefaultFace)
+		render.DrawCenteredScaledText(screen, "Press N or tap the bottom half for a new game", centerY+30, 1.5, color.White, render.DefaultFace)
+		return
+	}
+
 	// Draw the game onto the screen
-	g.renderSystem.Draw(screen)
+	g.renderSystem.SetScreen(screen)
+	g.world.Render()
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -114,11 +163,9 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 }
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
-	screen := game.NewScreen()
-	ebiten.SetWindowSize(screen.Width(), screen.Height())
+	ebiten.SetWindowSize(game.DefaultWidth, game.DefaultHeight)
 	ebiten.SetWindowTitle("ECS Asteroids")
+	ebiten.SetTPS(ebiten.SyncWithFPS)
 
 	if err := ebiten.RunGame(NewGame()); err != nil {
 		log.Fatal(err)
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/geom"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
@@ -19,24 +20,9 @@ const (
 	rearOffset = shipLength * 0.5  // Distance from center to rear
 )
 
-func DrawShip(screen *ebiten.Image, x, y, angle float64, isThrusting bool) {
-	// Ship vertices (triangle)
-	shipPoints := []struct{ x, y float64 }{
-		{-10, 10},  // Bottom left
-		{20, 0},    // Tip
-		{-10, -10}, // Bottom right
-	}
-
-	// Draw ship lines
-	for i := 0; i < len(shipPoints); i++ {
-		p1 := shipPoints[i]
-		p2 := shipPoints[(i+1)%len(shipPoints)]
-
-		transformed1 := transformPoint(p1.x, p1.y, x, y, angle)
-		transformed2 := transformPoint(p2.x, p2.y, x, y, angle)
-
-		drawLine(screen, transformed1, transformed2, color.White)
-	}
+// DrawShip draws the ship's hull, which is the same polygon it collides with.
+func DrawShip(screen *ebiten.Image, x, y, angle float64, hull geom.Polygon, isThrusting bool) {
+	drawPolygon(screen, hull.Place(x, y, angle), color.White)
 
 	// Draw thrusters if the ship is thrusting
 	if isThrusting {
@@ -76,32 +62,80 @@ func DrawBullet(screen *ebiten.Image, x, y float64) {
 	ebitenutil.DrawLine(screen, x-size, y+size, x+size, y-size, color.White)
 }
 
-func DrawAsteroid(screen *ebiten.Image, x, y, angle, scale float64) {
-	// Define asteroid shape as a rough circle with some variation
-	numPoints := 12
-	baseRadius := 20.0 * scale
-	points := make([]struct{ x, y float64 }, numPoints)
-
-	// Generate points
-	for i := 0; i < numPoints; i++ {
-		pointAngle := float64(i) * 2 * math.Pi / float64(numPoints)
-		// Add some randomness to the radius for each vertex
-		radius := baseRadius * (0.8 + 0.4*math.Sin(float64(i)*3))
-		points[i] = struct{ x, y float64 }{
-			x: radius * math.Cos(pointAngle),
-			y: radius * math.Sin(pointAngle),
+// DrawAsteroid draws an asteroid's outline, which is the same polygon it
+// collides with.
+func DrawAsteroid(screen *ebiten.Image, x, y, angle float64, outline geom.Polygon) {
+	drawPolygon(screen, outline.Place(x, y, angle), color.White)
+}
+
+// DrawSaucer draws a saucer's outline with a band across its widest part.
+func DrawSaucer(screen *ebiten.Image, x, y float64, outline geom.Polygon) {
+	hull := outline.Place(x, y, 0)
+	drawPolygon(screen, hull, color.White)
+
+	if len(hull.Points) == 0 {
+		return
+	}
+	left, right := hull.Points[0], hull.Points[0]
+	for _, p := range hull.Points {
+		if p.X < left.X {
+			left = p
+		}
+		if p.X > right.X {
+			right = p
 		}
 	}
+	ebitenutil.DrawLine(screen, left.X, left.Y, right.X, right.Y, color.White)
+}
+
+var powerUpColor = color.RGBA{R: 100, G: 200, B: 255, A: 255}
+
+// DrawPowerUp draws a pickup: a spinning hexagon around a glyph for its
+// kind.
+func DrawPowerUp(screen *ebiten.Image, x, y, angle float64, kind components.PowerUpKind) {
+	drawRing(screen, x, y, 12, 6, angle, powerUpColor)
 
-	// Draw lines between points
-	for i := 0; i < len(points); i++ {
-		p1 := points[i]
-		p2 := points[(i+1)%len(points)]
+	line := func(x1, y1, x2, y2 float64) {
+		ebitenutil.DrawLine(screen, x+x1, y+y1, x+x2, y+y2, powerUpColor)
+	}
+	switch kind {
+	case components.PowerUpShield:
+		drawRing(screen, x, y, 6, 12, 0, powerUpColor)
+	case components.PowerUpTripleShot:
+		line(0, 5, -5, -5)
+		line(0, 5, 0, -6)
+		line(0, 5, 5, -5)
+	case components.PowerUpRapidFire:
+		line(-5, -4, -1, 0)
+		line(-1, 0, -5, 4)
+		line(1, -4, 5, 0)
+		line(5, 0, 1, 4)
+	case components.PowerUpPiercing:
+		line(-6, 0, 6, 0)
+		line(6, 0, 2, -4)
+		line(6, 0, 2, 4)
+	case components.PowerUpExtraLife:
+		line(0, -6, 4, 5)
+		line(4, 5, -4, 5)
+		line(-4, 5, 0, -6)
+	}
+}
 
-		transformed1 := transformPoint(p1.x, p1.y, x, y, angle)
-		transformed2 := transformPoint(p2.x, p2.y, x, y, angle)
+// DrawShield draws the bubble around a shielded ship.
+func DrawShield(screen *ebiten.Image, x, y, radius float64) {
+	drawRing(screen, x, y, radius, 24, 0, powerUpColor)
+}
 
-		drawLine(screen, transformed1, transformed2, color.White)
+// drawRing draws a regular polygon with the given number of sides, which
+// passes for a circle once there are enough of them.
+func drawRing(screen *ebiten.Image, x, y, radius float64, sides int, angle float64, clr color.Color) {
+	for i := 0; i < sides; i++ {
+		a1 := angle + float64(i)*2*math.Pi/float64(sides)
+		a2 := angle + float64(i+1)*2*math.Pi/float64(sides)
+		ebitenutil.DrawLine(screen,
+			x+radius*math.Cos(a1), y+radius*math.Sin(a1),
+			x+radius*math.Cos(a2), y+radius*math.Sin(a2),
+			clr)
 	}
 }
 
@@ -151,6 +185,13 @@ func drawLine(screen *ebiten.Image, p1, p2 point, clr color.Color) {
 	ebitenutil.DrawLine(screen, p1.x, p1.y, p2.x, p2.y, clr)
 }
 
+func drawPolygon(screen *ebiten.Image, hull geom.Hull, clr color.Color) {
+	for i, p1 := range hull.Points {
+		p2 := hull.Points[(i+1)%len(hull.Points)]
+		ebitenutil.DrawLine(screen, p1.X, p1.Y, p2.X, p2.Y, clr)
+	}
+}
+
 // DrawLifeShip draws a small ship icon for the lives display
 func DrawLifeShip(screen *ebiten.Image, x, y float64) {
 	scale := 1.6  // Doubled from 0.8
@@ -0,0 +1,50 @@
+//go:build !(js && wasm)
+// +build !js !wasm
+
+package savegame
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const saveFile = "savegame.bin"
+
+// Save stores a world snapshot, replacing any previous save.
+func Save(data []byte) error {
+	dataDir := getDataDir()
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash mid-write can't leave a
+	// truncated save behind
+	filePath := filepath.Join(dataDir, saveFile)
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// Load returns the saved snapshot, if there is one.
+func Load() ([]byte, bool) {
+	data, err := os.ReadFile(filepath.Join(getDataDir(), saveFile))
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	return data, true
+}
+
+// Clear deletes the saved snapshot.
+func Clear() {
+	_ = os.Remove(filepath.Join(getDataDir(), saveFile))
+}
+
+func getDataDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+	return filepath.Join(homeDir, ".ecs-asteroids")
+}
@@ -0,0 +1,58 @@
+//go:build js && wasm
+// +build js,wasm
+
+package savegame
+
+import (
+	"encoding/base64"
+	"errors"
+	"syscall/js"
+)
+
+const saveKey = "asteroids_savegame"
+
+func localStorage() js.Value {
+	window := js.Global().Get("window")
+	if !window.Truthy() {
+		return js.Undefined()
+	}
+	return window.Get("localStorage")
+}
+
+// Save stores a world snapshot, replacing any previous save.
+func Save(data []byte) error {
+	storage := localStorage()
+	if !storage.Truthy() {
+		return errors.New("savegame: localStorage unavailable")
+	}
+
+	// localStorage only holds strings
+	storage.Call("setItem", saveKey, base64.StdEncoding.EncodeToString(data))
+	return nil
+}
+
+// Load returns the saved snapshot, if there is one.
+func Load() ([]byte, bool) {
+	storage := localStorage()
+	if !storage.Truthy() {
+		return nil, false
+	}
+
+	item := storage.Call("getItem", saveKey)
+	if item.IsNull() || item.IsUndefined() {
+		return nil, false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(item.String())
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	return data, true
+}
+
+// Clear deletes the saved snapshot.
+func Clear() {
+	if storage := localStorage(); storage.Truthy() {
+		storage.Call("removeItem", saveKey)
+	}
+}
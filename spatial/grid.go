@@ -0,0 +1,137 @@
+// Package spatial provides a uniform-grid spatial hash used as the collision
+// broadphase.
+package spatial
+
+import "math"
+
+// Grid buckets circles into cells so only circles sharing a cell are tested
+// against each other. Circles are identified by the index passed to Insert,
+// which callers use to look up their own data.
+//
+// The grid wraps around at its width and height, like the screen, so
+// circles straddling one edge share cells with circles at the opposite edge.
+type Grid struct {
+	cellW, cellH float64
+	wrapX, wrapY int32   // Cells across the torus
+	cells        [][]int // Row by row
+	bounds       []cellRange
+	seen         []int
+}
+
+// cellRange is the block of cells a circle's bounding box covers, starting
+// at (minX, minY) and spanning that many further cells.
+type cellRange struct {
+	minX, minY, spanX, spanY int32
+}
+
+// NewGrid returns an empty grid. Call Reset to size it before inserting.
+func NewGrid() *Grid {
+	return &Grid{}
+}
+
+// Reset empties the grid and makes it wrap around a width by height torus,
+// keeping the memory it has already allocated so rebuilding every frame
+// stays cheap. Cells are stretched slightly from cellSize so a whole number
+// of them fits across each side.
+func (g *Grid) Reset(width, height, cellSize float64) {
+	if cellSize <= 0 {
+		cellSize = 1
+	}
+	nx := int32(max(1, math.Floor(width/cellSize)))
+	ny := int32(max(1, math.Floor(height/cellSize)))
+	if nx != g.wrapX || ny != g.wrapY {
+		n := int(nx) * int(ny)
+		if cap(g.cells) < n {
+			g.cells = make([][]int, n)
+		}
+		g.cells = g.cells[:n]
+		g.wrapX, g.wrapY = nx, ny
+	}
+	g.cellW, g.cellH = width/float64(nx), height/float64(ny)
+
+	for i := range g.cells {
+		g.cells[i] = g.cells[i][:0]
+	}
+	g.bounds = g.bounds[:0]
+}
+
+// Len returns the number of circles in the grid.
+func (g *Grid) Len() int {
+	return len(g.bounds)
+}
+
+// Insert adds a circle. Indices must be inserted in order starting at 0.
+func (g *Grid) Insert(index int, x, y, radius float64) {
+	if index != len(g.bounds) {
+		panic("spatial: indices must be inserted in order")
+	}
+
+	r := g.rangeOf(x, y, radius)
+	g.bounds = append(g.bounds, r)
+
+	for oy := int32(0); oy <= r.spanY; oy++ {
+		for ox := int32(0); ox <= r.spanX; ox++ {
+			cell := g.cell(r.minX+ox, r.minY+oy)
+			g.cells[cell] = append(g.cells[cell], index)
+		}
+	}
+}
+
+// Pairs calls fn once for every pair of circles whose bounding boxes share
+// a cell, with a < b. Pairs are visited in a fixed order for a given
+// sequence of inserts, so simulations stay reproducible.
+func (g *Grid) Pairs(fn func(a, b int)) {
+	// Circles spanning several cells can share more than one; seen marks
+	// which circle each was last paired with, so the pair is only reported
+	// from the first of them
+	if cap(g.seen) < len(g.bounds) {
+		g.seen = make([]int, len(g.bounds))
+	}
+	g.seen = g.seen[:len(g.bounds)]
+	for i := range g.seen {
+		g.seen[i] = -1
+	}
+
+	for a, ra := range g.bounds {
+		for oy := int32(0); oy <= ra.spanY; oy++ {
+			for ox := int32(0); ox <= ra.spanX; ox++ {
+				for _, b := range g.cells[g.cell(ra.minX+ox, ra.minY+oy)] {
+					if b <= a || g.seen[b] == a {
+						continue
+					}
+					g.seen[b] = a
+					fn(a, b)
+				}
+			}
+		}
+	}
+}
+
+func (g *Grid) rangeOf(x, y, radius float64) cellRange {
+	minX := int32(math.Floor((x - radius) / g.cellW))
+	minY := int32(math.Floor((y - radius) / g.cellH))
+	maxX := int32(math.Floor((x + radius) / g.cellW))
+	maxY := int32(math.Floor((y + radius) / g.cellH))
+
+	// Covering the whole torus once is enough
+	return cellRange{
+		minX:  minX,
+		minY:  minY,
+		spanX: min(maxX-minX, g.wrapX-1),
+		spanY: min(maxY-minY, g.wrapY-1),
+	}
+}
+
+// cell returns the position in cells of cell (x, y), wrapped onto the
+// torus.
+func (g *Grid) cell(x, y int32) int {
+	return int(mod(y, g.wrapY))*int(g.wrapX) + int(mod(x, g.wrapX))
+}
+
+func mod(v, n int32) int32 {
+	v %= n
+	if v < 0 {
+		v += n
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type circle struct {
+	x, y, r float64
+}
+
+const (
+	testWidth  = 800
+	testHeight = 600
+)
+
+// pairs fills a grid with circles and returns how often each pair was
+// reported, failing on a pair out of order.
+func pairs(t *testing.T, g *Grid, cellSize float64, circles []circle) map[[2]int]int {
+	t.Helper()
+	g.Reset(testWidth, testHeight, cellSize)
+	for i, c := range circles {
+		g.Insert(i, c.x, c.y, c.r)
+	}
+
+	seen := make(map[[2]int]int)
+	g.Pairs(func(a, b int) {
+		if a >= b {
+			t.Errorf("pair (%d, %d) is out of order", a, b)
+		}
+		seen[[2]int{a, b}]++
+	})
+	for pair, n := range seen {
+		if n > 1 {
+			t.Errorf("pair %v reported %d times", pair, n)
+		}
+	}
+	return seen
+}
+
+func TestGridWrapEdges(t *testing.T) {
+	circles := []circle{
+		{2, 300, 5},     // Left edge
+		{798, 300, 5},   // Right edge, touching 0 across the seam
+		{400, 1, 5},     // Top edge
+		{400, 599, 5},   // Bottom edge, touching 2 across the seam
+		{1, 1, 5},       // Top left corner
+		{799, 599, 5},   // Bottom right corner, touching 4 diagonally
+		{400, 300, 5},   // Middle, touching nothing
+		{200, 300, 500}, // Larger than the screen, touching everything
+	}
+	seen := pairs(t, NewGrid(), 50, circles)
+
+	for _, want := range [][2]int{{0, 1}, {2, 3}, {4, 5}, {0, 7}, {6, 7}} {
+		if seen[want] == 0 {
+			t.Errorf("pair %v across the seam was not reported", want)
+		}
+	}
+	for pair := range seen {
+		if pair[0] == 6 && pair[1] != 7 {
+			t.Errorf("middle circle paired with %d", pair[1])
+		}
+	}
+}
+
+func TestGridMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	g := NewGrid()
+
+	for layout := 0; layout < 50; layout++ {
+		circles := make([]circle, 100)
+		for i := range circles {
+			circles[i] = circle{
+				x: rng.Float64() * testWidth,
+				y: rng.Float64() * testHeight,
+				r: 1 + rng.Float64()*rng.Float64()*150,
+			}
+		}
+		cellSize := 10 + rng.Float64()*200
+		seen := pairs(t, g, cellSize, circles)
+
+		// Every pair close enough to overlap on the torus must be reported
+		for a := range circles {
+			for b := a + 1; b < len(circles); b++ {
+				ca, cb := circles[a], circles[b]
+				dx := wrapDelta(cb.x-ca.x, testWidth)
+				dy := wrapDelta(cb.y-ca.y, testHeight)
+				if math.Hypot(dx, dy) < ca.r+cb.r && seen[[2]int{a, b}] == 0 {
+					t.Fatalf("layout %d, cell size %.1f: overlapping pair (%d, %d) missed", layout, cellSize, a, b)
+				}
+			}
+		}
+	}
+}
+
+func wrapDelta(d, size float64) float64 {
+	d = math.Mod(d, size)
+	if d > size/2 {
+		d -= size
+	} else if d < -size/2 {
+		d += size
+	}
+	return d
+}
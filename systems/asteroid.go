@@ -0,0 +1,131 @@
+package systems
+
+import (
+	"math"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+)
+
+// impact describes what struck an asteroid: where, how fast and how heavy.
+type impact struct {
+	X, Y   float64
+	DX, DY float64
+	Mass   float64
+}
+
+// impactOf describes the contact as something striking an asteroid.
+func impactOf(world *ecs.World, c Contact) impact {
+	vel, _ := ecs.Get[components.Velocity](world, c.ID)
+	return impact{
+		X:    c.Position.X,
+		Y:    c.Position.Y,
+		DX:   vel.DX,
+		DY:   vel.DY,
+		Mass: rigidBodyOf(world, c.ID, c.Collider).Mass,
+	}
+}
+
+// breakAsteroid destroys an asteroid that was struck and, unless it was the
+// smallest size, throws out its fragments according to the impact. by is
+// who gets the credit, or ecs.NoEntity when nobody does.
+func breakAsteroid(world *ecs.World, asteroidID, by ecs.EntityID, hit impact) {
+	asteroid, ok := ecs.Get[components.Asteroid](world, asteroidID)
+	if !ok {
+		return
+	}
+
+	pos, _ := ecs.Get[components.Position](world, asteroidID)
+	vel, _ := ecs.Get[components.Velocity](world, asteroidID)
+	rot, _ := ecs.Get[components.Rotation](world, asteroidID)
+	col, _ := ecs.Get[components.Collider](world, asteroidID)
+	body := rigidBodyOf(world, asteroidID, col)
+	screen := game.ScreenOf(world)
+
+	commands := world.Commands()
+
+	ecs.Publish(world, events.AsteroidDestroyed{
+		Asteroid: asteroidID,
+		Size:     asteroid.Size,
+		X:        pos.X,
+		Y:        pos.Y,
+		By:       by,
+	})
+
+	// Destroy the hit asteroid
+	commands.Destroy(asteroidID)
+
+	// Unless it was the smallest size, break it into fragments
+	if len(asteroid.SplitInto) == 0 {
+		return
+	}
+
+	// Offset from the impact point to the centre, across the screen edge if
+	// that is nearer
+	awayX, awayY := screen.WrapDelta(pos.X-hit.X, pos.Y-hit.Y)
+
+	// The impact pushes along the impactor's path, bent away from the side
+	// it struck, so a glancing shot deflects the pieces sideways
+	speed := math.Hypot(hit.DX, hit.DY)
+	dirX, dirY := 1.0, 0.0
+	if speed > 0 {
+		dirX, dirY = hit.DX/speed, hit.DY/speed
+	}
+	baseAngle := math.Atan2(dirY+awayY/col.Radius, dirX+awayX/col.Radius)
+
+	// Off-centre hits set the pieces spinning
+	momentumX, momentumY := hit.DX*hit.Mass, hit.DY*hit.Mass
+	spin := (-awayX*momentumY + awayY*momentumX) / body.Inertia
+
+	// Each fragment takes an equal share of the parent's mass, so lighter
+	// fragments are thrown harder
+	count := len(asteroid.SplitInto)
+	kick := asteroid.Blast * hit.Mass * speed / (body.Mass / float64(count))
+
+	for i, prefab := range asteroid.SplitInto {
+		angle := baseAngle
+		if count > 1 {
+			angle += asteroid.Spread * (float64(i)/float64(count-1) - 0.5)
+		}
+		dx, dy := math.Cos(angle), math.Sin(angle)
+
+		fragmentVel, _ := game.PrefabComponent[components.Velocity](prefab)
+		fragmentVel.DX = vel.DX + dx*kick
+		fragmentVel.DY = vel.DY + dy*kick
+		if speed := math.Hypot(fragmentVel.DX, fragmentVel.DY); speed > fragmentVel.MaxSpeed {
+			fragmentVel.DX *= fragmentVel.MaxSpeed / speed
+			fragmentVel.DY *= fragmentVel.MaxSpeed / speed
+		}
+
+		// Start each fragment a little way out so they don't overlap
+		fragmentCol, _ := game.PrefabComponent[components.Collider](prefab)
+		fragmentPos := components.Position{
+			X: pos.X + dx*fragmentCol.Radius*0.5,
+			Y: pos.Y + dy*fragmentCol.Radius*0.5,
+		}
+		fragmentPos.X, fragmentPos.Y = screen.WrapCoordinates(fragmentPos.X, fragmentPos.Y)
+
+		commands.Spawn(func(w *ecs.World) {
+			fragment := game.CreateAsteroidFromPrefab(w, prefab)
+			ecs.Set(w, fragment, fragmentPos)
+			ecs.Set(w, fragment, fragmentVel)
+
+			// Keep the random orientation but carry on the parent's spin
+			fragmentRot, _ := ecs.Get[components.Rotation](w, fragment)
+			fragmentRot.RotationSpeed = rot.RotationSpeed + spin
+			ecs.Set(w, fragment, fragmentRot)
+		})
+	}
+}
+
+// rigidBodyOf returns the entity's rigid body. Bodies given only a mass, like
+// bullets, ships and saucers, spin as a solid disc the size of their collider.
+func rigidBodyOf(world *ecs.World, id ecs.EntityID, col components.Collider) components.RigidBody {
+	body, _ := ecs.Get[components.RigidBody](world, id)
+	if body.Inertia <= 0 {
+		body.Inertia = 0.5 * body.Mass * col.Radius * col.Radius
+	}
+	return body
+}
@@ -3,115 +3,250 @@ package systems
 import (
 	"fmt"
 	"math"
-	"math/rand"
+	"sort"
 
 	"github.com/bobbyhiddn/ecs-asteroids/components"
 	"github.com/bobbyhiddn/ecs-asteroids/ecs"
 	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/geom"
+	"github.com/bobbyhiddn/ecs-asteroids/spatial"
+)
+
+const (
+	minGridCells = 4   // Fewest broadphase cells across the screen
+	maxGridCells = 256 // Most broadphase cells across the screen
 )
 
 type CollisionSystem struct {
-	world  *ecs.World
-	screen *game.Screen
+	world      *ecs.World
+	screen     *game.Screen
+	colliders  *ecs.Store[components.Collider]
+	positions  *ecs.Store[components.Position]
+	velocities *ecs.Store[components.Velocity]
+	rotations  *ecs.Store[components.Rotation]
+	fastMovers *ecs.Store[components.FastMover]
+	previous   *ecs.Store[components.PreviousTransform]
+	collidable *ecs.Query
+	grid       *spatial.Grid
+	handlers   *collisionHandlers
+	candidates []collisionCandidate
+	sweptHits  []sweptHit
+}
+
+// collisionCandidate is a collider gathered for the broadphase. Fast movers
+// are swept from where they started the step.
+type collisionCandidate struct {
+	id    ecs.EntityID
+	pos   components.Position
+	col   components.Collider
+	from  components.Position
+	swept bool
+}
+
+// sweptHit is a fast mover's contact, held back so every fast mover hits
+// whatever it reached first during the step.
+type sweptHit struct {
+	toi    float64 // Fraction of the step at which they touch
+	c1, c2 Contact
 }
 
 func NewCollisionSystem(world *ecs.World) *CollisionSystem {
-	return &CollisionSystem{
-		world:  world,
-		screen: game.NewScreen(),
+	s := &CollisionSystem{
+		world:      world,
+		screen:     game.ScreenOf(world),
+		colliders:  ecs.Register[components.Collider](world),
+		positions:  ecs.Register[components.Position](world),
+		velocities: ecs.Register[components.Velocity](world),
+		rotations:  ecs.Register[components.Rotation](world),
+		fastMovers: ecs.Register[components.FastMover](world),
+		previous:   ecs.Register[components.PreviousTransform](world),
+		grid:       spatial.NewGrid(),
+		handlers:   collisionHandlersOf(world),
 	}
+	s.collidable = ecs.NewQuery(s.colliders, s.positions)
+
+	OnCollision(world, components.LayerAsteroid, components.LayerAsteroid, func(a, b Contact) {
+		s.handleAsteroidCollision(a.ID, b.ID, a.Position, b.Position, a.Collider, b.Collider)
+	})
+	OnCollision(world, components.LayerShip, components.LayerAsteroid, func(ship, asteroid Contact) {
+		if shielded(world, ship.ID) {
+			// A shielded ship smashes straight through
+			breakAsteroid(world, asteroid.ID, ship.ID, impactOf(world, ship))
+			return
+		}
+		destroyShip(world, ship.ID)
+	})
+	OnCollision(world, components.LayerBullet, components.LayerAsteroid, func(bullet, asteroid Contact) {
+		breakAsteroid(world, asteroid.ID, shooterOf(world, bullet.ID), impactOf(world, bullet))
+		spendBullet(world, bullet.ID)
+	})
+	return s
 }
 
 func (s *CollisionSystem) Update(dt float64) {
-	colliders := s.world.Components["components.Collider"]
-	positions := s.world.Components["components.Position"]
-
-	// Create a list of all entities with colliders
-	var entities []ecs.EntityID
-	for id := range colliders {
-		entities = append(entities, id)
+	commands := s.world.Commands()
+	entities := s.collidable.IDs()
+
+	// Gather positions and colliders up front and size the grid cells to
+	// fit a typical collider. Larger ones simply cover several cells.
+	s.candidates = s.candidates[:0]
+	totalRadius := 0.0
+	for _, id := range entities {
+		pos, _ := s.positions.Get(id)
+		col, _ := s.colliders.Get(id)
+		c := collisionCandidate{id: id, pos: pos, col: col}
+		if prev, ok := s.previous.Get(id); ok && s.fastMovers.Has(id) {
+			dx, dy := s.screen.WrapDelta(pos.X-prev.X, pos.Y-prev.Y)
+			c.from = components.Position{X: pos.X - dx, Y: pos.Y - dy}
+			c.swept = true
+		}
+		s.candidates = append(s.candidates, c)
+		totalRadius += col.Radius
+	}
+	s.grid.Reset(float64(s.screen.Width()), float64(s.screen.Height()), s.cellSize(totalRadius))
+	for i, c := range s.candidates {
+		if c.swept {
+			// Cover the whole path travelled this step
+			length := math.Hypot(c.pos.X-c.from.X, c.pos.Y-c.from.Y)
+			s.grid.Insert(i, (c.from.X+c.pos.X)/2, (c.from.Y+c.pos.Y)/2, length/2+c.col.Radius)
+			continue
+		}
+		s.grid.Insert(i, c.pos.X, c.pos.Y, c.col.Radius)
 	}
+	s.sweptHits = s.sweptHits[:0]
+
+	// Check each nearby pair for collisions. Entities destroyed earlier in
+	// this pass stay in the world until the next flush, so they are skipped
+	// explicitly rather than being hit a second time.
+	s.grid.Pairs(func(i, j int) {
+		id1, id2 := s.candidates[i].id, s.candidates[j].id
+		col1, col2 := s.candidates[i].col, s.candidates[j].col
+		if commands.Destroyed(id1) || commands.Destroyed(id2) || !collides(col1, col2) {
+			return
+		}
 
-	// Check each pair of entities for collisions
-	for i := 0; i < len(entities); i++ {
-		id1 := entities[i]
-		pos1, ok1 := positions[id1].(components.Position)
-		col1, ok2 := colliders[id1].(components.Collider)
+		// Earlier responses in this pass may have moved either entity
+		pos1, ok1 := s.positions.Get(id1)
+		pos2, ok2 := s.positions.Get(id2)
 		if !ok1 || !ok2 {
-			continue
+			return
+		}
+
+		if s.candidates[i].swept || s.candidates[j].swept {
+			s.sweep(i, j, pos1, pos2)
+			return
+		}
+
+		// Measure the distance across the screen edges when that is shorter,
+		// and treat the second entity as sitting at its nearest image
+		dx, dy := s.screen.WrapDelta(pos1.X-pos2.X, pos1.Y-pos2.Y)
+		distance := math.Sqrt(dx*dx + dy*dy)
+		pos2.X, pos2.Y = pos1.X-dx, pos1.Y-dy
+
+		// Check if collision occurred: the enclosing circles first, then
+		// the exact shapes
+		if distance < col1.Radius+col2.Radius && s.shapesOverlap(id1, pos1, col1, id2, pos2, col2) {
+			s.handlers.dispatch(commands,
+				Contact{ID: id1, Position: pos1, Collider: col1},
+				Contact{ID: id2, Position: pos2, Collider: col2})
 		}
+	})
 
-		for j := i + 1; j < len(entities); j++ {
-			id2 := entities[j]
-			pos2, ok1 := positions[id2].(components.Position)
-			col2, ok2 := colliders[id2].(components.Collider)
-			if !ok1 || !ok2 {
-				continue
-			}
-
-			// Calculate distance between entities
-			dx := pos1.X - pos2.X
-			dy := pos1.Y - pos2.Y
-			distance := math.Sqrt(dx*dx + dy*dy)
-
-			// Check if collision occurred
-			if distance < col1.Radius+col2.Radius {
-				// Determine collision type
-				isAsteroid1 := col1.Type == components.ColliderTypeAsteroid
-				isAsteroid2 := col2.Type == components.ColliderTypeAsteroid
-				isShip1 := col1.Type == components.ColliderTypeShip
-				isShip2 := col2.Type == components.ColliderTypeShip
-				isBullet1 := col1.Type == components.ColliderTypeBullet
-				isBullet2 := col2.Type == components.ColliderTypeBullet
-
-				// Handle different collision types
-				switch {
-				// Asteroid-Asteroid collisions
-				case isAsteroid1 && isAsteroid2:
-					s.handleAsteroidCollision(id1, id2, pos1, pos2, col1, col2)
-
-				// Ship-Asteroid collisions
-				case isShip1 && isAsteroid2:
-					if !s.isInvulnerable(id1) {
-						s.handleShipHit(id1)
-					}
-				case isShip2 && isAsteroid1:
-					if !s.isInvulnerable(id2) {
-						s.handleShipHit(id2)
-					}
-
-				// Bullet-Asteroid collisions
-				case isBullet1 && isAsteroid2:
-					if shooter := s.findShooter(id1); shooter != 0 {
-						s.awardPoints(shooter, id2)
-					}
-					s.handleAsteroidHit(id2)
-					s.world.DestroyEntity(id1)
-				case isBullet2 && isAsteroid1:
-					if shooter := s.findShooter(id2); shooter != 0 {
-						s.awardPoints(shooter, id1)
-					}
-					s.handleAsteroidHit(id1)
-					s.world.DestroyEntity(id2)
-				}
-			}
+	// Resolve fast movers' hits in the order they happened, so a bullet
+	// stops at the first asteroid on its path
+	sort.SliceStable(s.sweptHits, func(a, b int) bool {
+		return s.sweptHits[a].toi < s.sweptHits[b].toi
+	})
+	for _, hit := range s.sweptHits {
+		if commands.Destroyed(hit.c1.ID) || commands.Destroyed(hit.c2.ID) {
+			continue
 		}
+		s.handlers.dispatch(commands, hit.c1, hit.c2)
 	}
 }
 
+// sweep tests a fast mover's path through the step against another
+// collider, taken at its end-of-step position, and records the earliest
+// contact.
+func (s *CollisionSystem) sweep(i, j int, pos1, pos2 components.Position) {
+	mover, other, otherPos := s.candidates[i], s.candidates[j], pos2
+	if !mover.swept {
+		mover, other, otherPos = s.candidates[j], s.candidates[i], pos1
+	}
+
+	// Use the other collider's image nearest the middle of the path
+	from := geom.Point{X: mover.from.X, Y: mover.from.Y}
+	to := geom.Point{X: mover.pos.X, Y: mover.pos.Y}
+	midX, midY := (from.X+to.X)/2, (from.Y+to.Y)/2
+	dx, dy := s.screen.WrapDelta(otherPos.X-midX, otherPos.Y-midY)
+	target := components.Position{X: midX + dx, Y: midY + dy}
+
+	var toi float64
+	var hit bool
+	if other.col.Polygon != nil {
+		toi, hit = geom.SweepCircleHull(from, to, mover.col.Radius, s.hull(other.id, target, other.col))
+	} else {
+		toi, hit = geom.SweepCircle(from, to, mover.col.Radius, geom.Point{X: target.X, Y: target.Y}, other.col.Radius)
+	}
+	if !hit {
+		return
+	}
+
+	at := components.Position{X: from.X + (to.X-from.X)*toi, Y: from.Y + (to.Y-from.Y)*toi}
+	s.sweptHits = append(s.sweptHits, sweptHit{
+		toi: toi,
+		c1:  Contact{ID: mover.id, Position: at, Collider: mover.col},
+		c2:  Contact{ID: other.id, Position: target, Collider: other.col},
+	})
+}
+
+// shapesOverlap tests two colliders whose enclosing circles overlap against
+// their polygons, when they have them.
+func (s *CollisionSystem) shapesOverlap(id1 ecs.EntityID, pos1 components.Position, col1 components.Collider, id2 ecs.EntityID, pos2 components.Position, col2 components.Collider) bool {
+	switch {
+	case col1.Polygon != nil && col2.Polygon != nil:
+		return geom.HullsOverlap(s.hull(id1, pos1, col1), s.hull(id2, pos2, col2))
+	case col1.Polygon != nil:
+		return geom.HullCircleOverlap(s.hull(id1, pos1, col1), geom.Point{X: pos2.X, Y: pos2.Y}, col2.Radius)
+	case col2.Polygon != nil:
+		return geom.HullCircleOverlap(s.hull(id2, pos2, col2), geom.Point{X: pos1.X, Y: pos1.Y}, col1.Radius)
+	}
+	// Two circles already overlap
+	return true
+}
+
+func (s *CollisionSystem) hull(id ecs.EntityID, pos components.Position, col components.Collider) geom.Hull {
+	rot, _ := s.rotations.Get(id)
+	return col.Polygon.Place(pos.X, pos.Y, rot.Angle)
+}
+
+// cellSize picks the broadphase cell size from the colliders' average
+// diameter, bounded so the screen is never split into more than
+// maxGridCells cells per side or fewer than minGridCells.
+func (s *CollisionSystem) cellSize(totalRadius float64) float64 {
+	extent := float64(max(s.screen.Width(), s.screen.Height()))
+	size := extent / minGridCells
+	if n := len(s.candidates); n > 0 {
+		size = 2 * totalRadius / float64(n)
+	}
+	return math.Min(math.Max(size, extent/maxGridCells), extent/minGridCells)
+}
+
 func (s *CollisionSystem) handleAsteroidCollision(id1, id2 ecs.EntityID, pos1, pos2 components.Position, col1, col2 components.Collider) {
 	// Get velocities
-	vel1Interface := s.world.Components["components.Velocity"][id1]
-	vel2Interface := s.world.Components["components.Velocity"][id2]
+	vel1, ok1 := s.velocities.Get(id1)
+	vel2, ok2 := s.velocities.Get(id2)
 
 	// Check if velocities exist
-	if vel1Interface == nil || vel2Interface == nil {
+	if !ok1 || !ok2 {
 		// One of the asteroids was probably already destroyed
 		return
 	}
-
-	vel1 := vel1Interface.(components.Velocity)
-	vel2 := vel2Interface.(components.Velocity)
+	rot1, _ := s.rotations.Get(id1)
+	rot2, _ := s.rotations.Get(id2)
+	before1, before2 := vel1, vel2
+	body1 := rigidBodyOf(s.world, id1, col1)
+	body2 := rigidBodyOf(s.world, id2, col2)
 
 	fmt.Printf("Before collision - Asteroid 1: vel=(%f, %f), pos=(%f, %f)\n", vel1.DX, vel1.DY, pos1.X, pos1.Y)
 	fmt.Printf("Before collision - Asteroid 2: vel=(%f, %f), pos=(%f, %f)\n", vel2.DX, vel2.DY, pos2.X, pos2.Y)
@@ -120,64 +255,58 @@ func (s *CollisionSystem) handleAsteroidCollision(id1, id2 ecs.EntityID, pos1, p
 	dx := pos2.X - pos1.X
 	dy := pos2.Y - pos1.Y
 	dist := math.Sqrt(dx*dx + dy*dy)
+	if dist == 0 {
+		return
+	}
 	nx := dx / dist
 	ny := dy / dist
+	tx := -ny // Tangent vector is perpendicular to normal
+	ty := nx
 
-	// Calculate relative velocity
-	dvx := vel2.DX - vel1.DX
-	dvy := vel2.DY - vel1.DY
+	// Contact point on the line between the centres, split by size, and
+	// the arms from each centre to it
+	cx := pos1.X + nx*dist*col1.Radius/(col1.Radius+col2.Radius)
+	cy := pos1.Y + ny*dist*col1.Radius/(col1.Radius+col2.Radius)
+	r1x, r1y := cx-pos1.X, cy-pos1.Y
+	r2x, r2y := cx-pos2.X, cy-pos2.Y
 
-	// Calculate relative velocity along normal
-	velAlongNormal := dvx*nx + dvy*ny
+	// Relative velocity at the contact, including each body's spin
+	dvx := (vel2.DX - rot2.RotationSpeed*r2y) - (vel1.DX - rot1.RotationSpeed*r1y)
+	dvy := (vel2.DY + rot2.RotationSpeed*r2x) - (vel1.DY + rot1.RotationSpeed*r1x)
 
 	// If asteroids are moving apart, don't bounce
+	velAlongNormal := dvx*nx + dvy*ny
 	if velAlongNormal > 0 {
 		return
 	}
 
-	// Calculate collision response
-	restitution := 0.8 // Coefficient of restitution (0.8 = 80% energy conservation)
-	j := -(1.0 + restitution) * velAlongNormal
-	j *= 0.5 // Assuming equal masses
-
-	// Calculate new velocities
-	vel1New := components.Velocity{
-		DX:       vel1.DX - j*nx,
-		DY:       vel1.DY - j*ny,
-		MaxSpeed: vel1.MaxSpeed,
-	}
-
-	vel2New := components.Velocity{
-		DX:       vel2.DX + j*nx,
-		DY:       vel2.DY + j*ny,
-		MaxSpeed: vel2.MaxSpeed,
-	}
-
-	// Add perpendicular impulse to create more interesting collisions
-	// This adds some angular momentum to the collision
-	tx := -ny // Tangent vector is perpendicular to normal
-	ty := nx
-	tangentImpulse := (rand.Float64() - 0.5) * 50.0
-
-	vel1New.DX += tx * tangentImpulse
-	vel1New.DY += ty * tangentImpulse
-	vel2New.DX -= tx * tangentImpulse
-	vel2New.DY -= ty * tangentImpulse
-
-	// Enforce speed constraints for both asteroids
-	minSpeed := 100.0
-	for _, vel := range []*components.Velocity{&vel1New, &vel2New} {
+	// Normal impulse, weighted by mass
+	invMass := 1/body1.Mass + 1/body2.Mass
+	restitution := math.Min(body1.Restitution, body2.Restitution)
+	j := -(1.0 + restitution) * velAlongNormal / invMass
+
+	// Friction impulse along the tangent, limited by the normal impulse.
+	// This is what trades linear motion for spin.
+	r1t := r1x*ty - r1y*tx
+	r2t := r2x*ty - r2y*tx
+	velAlongTangent := dvx*tx + dvy*ty
+	jt := -velAlongTangent / (invMass + r1t*r1t/body1.Inertia + r2t*r2t/body2.Inertia)
+	friction := math.Sqrt(body1.Friction * body2.Friction)
+	jt = math.Max(-friction*j, math.Min(friction*j, jt))
+
+	// Apply the impulses to both bodies
+	px := j*nx + jt*tx
+	py := j*ny + jt*ty
+	vel1.DX -= px / body1.Mass
+	vel1.DY -= py / body1.Mass
+	vel2.DX += px / body2.Mass
+	vel2.DY += py / body2.Mass
+	rot1.RotationSpeed -= (r1x*py - r1y*px) / body1.Inertia
+	rot2.RotationSpeed += (r2x*py - r2y*px) / body2.Inertia
+
+	// Respect each asteroid's speed limit
+	for _, vel := range []*components.Velocity{&vel1, &vel2} {
 		speed := math.Sqrt(vel.DX*vel.DX + vel.DY*vel.DY)
-
-		// Apply minimum speed
-		if speed < minSpeed {
-			scale := minSpeed / speed
-			vel.DX *= scale
-			vel.DY *= scale
-			continue // Skip max speed check if we just scaled up
-		}
-
-		// Apply maximum speed
 		if speed > vel.MaxSpeed {
 			scale := vel.MaxSpeed / speed
 			vel.DX *= scale
@@ -185,28 +314,47 @@ func (s *CollisionSystem) handleAsteroidCollision(id1, id2 ecs.EntityID, pos1, p
 		}
 	}
 
-	// Update velocities
-	s.world.AddComponent(id1, vel1New)
-	s.world.AddComponent(id2, vel2New)
+	// Update velocities and spin
+	s.velocities.Set(id1, vel1)
+	s.velocities.Set(id2, vel2)
+	s.rotations.Set(id1, rot1)
+	s.rotations.Set(id2, rot2)
 
-	// Separate asteroids to prevent sticking
+	// Separate asteroids to prevent sticking, the lighter one moving further
 	overlap := (col1.Radius + col2.Radius) - dist
 	if overlap > 0 {
-		separation := overlap / 2
-		pos1.X -= nx * separation
-		pos1.Y -= ny * separation
-		pos2.X += nx * separation
-		pos2.Y += ny * separation
+		share1 := body2.Mass / (body1.Mass + body2.Mass)
+		pos1.X -= nx * overlap * share1
+		pos1.Y -= ny * overlap * share1
+		pos2.X += nx * overlap * (1 - share1)
+		pos2.Y += ny * overlap * (1 - share1)
 
 		s.wrapPosition(&pos1)
 		s.wrapPosition(&pos2)
 
-		s.world.AddComponent(id1, pos1)
-		s.world.AddComponent(id2, pos2)
+		s.positions.Set(id1, pos1)
+		s.positions.Set(id2, pos2)
 	}
 
-	fmt.Printf("After collision - Asteroid 1: vel=(%f, %f), pos=(%f, %f)\n", vel1New.DX, vel1New.DY, pos1.X, pos1.Y)
-	fmt.Printf("After collision - Asteroid 2: vel=(%f, %f), pos=(%f, %f)\n", vel2New.DX, vel2New.DY, pos2.X, pos2.Y)
+	fmt.Printf("After collision - Asteroid 1: vel=(%f, %f), pos=(%f, %f)\n", vel1.DX, vel1.DY, pos1.X, pos1.Y)
+	fmt.Printf("After collision - Asteroid 2: vel=(%f, %f), pos=(%f, %f)\n", vel2.DX, vel2.DY, pos2.X, pos2.Y)
+
+	// A hard enough knock from something smaller breaks the heavier one
+	heavy, heavyBody, light, lightBody := id1, body1, before2, body2
+	if body2.Mass > body1.Mass {
+		heavy, heavyBody, light, lightBody = id2, body2, before1, body1
+	}
+	reducedMass := body1.Mass * body2.Mass / (body1.Mass + body2.Mass)
+	energy := 0.5 * reducedMass * velAlongNormal * velAlongNormal
+	if heavyBody.FractureEnergy > 0 && energy > heavyBody.FractureEnergy {
+		breakAsteroid(s.world, heavy, ecs.NoEntity, impact{
+			X:    cx,
+			Y:    cy,
+			DX:   light.DX,
+			DY:   light.DY,
+			Mass: lightBody.Mass,
+		})
+	}
 }
 
 func (s *CollisionSystem) wrapPosition(pos *components.Position) {
@@ -225,154 +373,3 @@ func (s *CollisionSystem) wrapPosition(pos *components.Position) {
 		pos.Y -= height
 	}
 }
-
-func (s *CollisionSystem) handleShipHit(shipID ecs.EntityID) {
-	// Check if ship is invulnerable
-	if _, ok := s.world.Components["components.Invulnerable"][shipID]; ok {
-		return // Skip collision if ship is invulnerable
-	}
-
-	player, ok := s.world.Components["components.Player"][shipID].(components.Player)
-	if !ok {
-		return
-	}
-
-	// Get current position for explosion and respawn
-	pos := s.world.Components["components.Position"][shipID].(components.Position)
-
-	// Create explosion at ship's position
-	game.CreateExplosion(s.world, pos.X, pos.Y, 30.0) // Size matches ship roughly
-
-	// Reduce lives
-	player.Lives--
-
-	// Check for game over
-	if player.Lives <= 0 {
-		player.IsGameOver = true
-		player.Lives = 0 // Ensure it doesn't go negative
-
-		// Update player component
-		s.world.AddComponent(shipID, player)
-
-		// Remove all components except Player and Input to effectively disable the ship
-		// but keep the player state for restart
-		for componentName, components := range s.world.Components {
-			if componentName != "components.Player" && componentName != "components.Input" {
-				if _, exists := components[shipID]; exists {
-					delete(s.world.Components[componentName], shipID)
-				}
-			}
-		}
-		return
-	}
-
-	// Update player component
-	s.world.AddComponent(shipID, player)
-
-	// Reset velocity
-	s.world.AddComponent(shipID, components.Velocity{MaxSpeed: 400})
-
-	// Reset rotation
-	s.world.AddComponent(shipID, components.Rotation{})
-
-	// Move ship back to center and make invulnerable
-	pos.X = s.screen.CenterX()
-	pos.Y = s.screen.CenterY()
-	s.wrapPosition(&pos)
-	s.world.AddComponent(shipID, pos)
-
-	// Add invulnerability
-	s.world.AddComponent(shipID, components.Invulnerable{
-		Duration: 3.0, // 3 seconds of invulnerability
-		Timer:    3.0,
-	})
-}
-
-func (s *CollisionSystem) handleAsteroidHit(asteroidID ecs.EntityID) {
-	asteroid, ok := s.world.Components["components.Asteroid"][asteroidID].(components.Asteroid)
-	if !ok {
-		return
-	}
-
-	pos := s.world.Components["components.Position"][asteroidID].(components.Position)
-	vel := s.world.Components["components.Velocity"][asteroidID].(components.Velocity)
-
-	// Create explosion effect
-	game.CreateExplosion(s.world, pos.X, pos.Y, float64(20+asteroid.Size*10))
-
-	// Destroy the hit asteroid
-	s.world.DestroyEntity(asteroidID)
-
-	// If it wasn't the smallest size, spawn two smaller asteroids
-	if asteroid.Size > 0 {
-		// Calculate base angle from current velocity
-		angle := math.Atan2(vel.DY, vel.DX)
-
-		for i := 0; i < 2; i++ {
-			newAsteroid := game.CreateAsteroid(s.world, asteroid.Size-1)
-
-			// Position at split point
-			s.world.AddComponent(newAsteroid, components.Position{
-				X: pos.X,
-				Y: pos.Y,
-			})
-
-			// Calculate new velocity
-			splitAngle := angle
-			if i == 1 {
-				splitAngle = angle - math.Pi/3
-			} else {
-				splitAngle = angle + math.Pi/3
-			}
-
-			speed := math.Sqrt(vel.DX*vel.DX+vel.DY*vel.DY) * 1.5
-			s.world.AddComponent(newAsteroid, components.Velocity{
-				DX:       math.Cos(splitAngle) * speed,
-				DY:       math.Sin(splitAngle) * speed,
-				MaxSpeed: speed * 1.2,
-			})
-		}
-	}
-}
-
-func (s *CollisionSystem) findShooter(bulletID ecs.EntityID) ecs.EntityID {
-	if bullet, ok := s.world.Components["components.Bullet"][bulletID].(components.Bullet); ok {
-		// Find player with matching ID
-		for id := range s.world.Components["components.Player"] {
-			if int(id) == bullet.ShooterID {
-				return id
-			}
-		}
-	}
-	return 0
-}
-
-func (s *CollisionSystem) awardPoints(playerID ecs.EntityID, asteroidID ecs.EntityID) {
-	player, ok := s.world.Components["components.Player"][playerID].(components.Player)
-	if !ok {
-		return
-	}
-
-	asteroid, ok := s.world.Components["components.Asteroid"][asteroidID].(components.Asteroid)
-	if !ok {
-		return
-	}
-
-	// Award points based on asteroid size
-	switch asteroid.Size {
-	case 0: // Small
-		player.Score += 100
-	case 1: // Medium
-		player.Score += 50
-	case 2: // Large
-		player.Score += 20
-	}
-
-	// Update player score
-	s.world.AddComponent(playerID, player)
-}
-
-func (s *CollisionSystem) isInvulnerable(entityID ecs.EntityID) bool {
-	_, hasInvulnerable := s.world.Components["components.Invulnerable"][entityID]
-	return hasInvulnerable
-}
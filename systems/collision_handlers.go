@@ -0,0 +1,71 @@
+package systems
+
+import (
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+)
+
+// Contact is one side of a collision. Position is where the entity is as
+// seen from the other side, which may be past a screen edge when the two
+// touch across it; wrap it before storing it.
+type Contact struct {
+	ID       ecs.EntityID
+	Position components.Position
+	Collider components.Collider
+}
+
+// CollisionHandler responds to two colliders touching. a is on the first
+// layer the handler was registered for and b on the second.
+type CollisionHandler func(a, b Contact)
+
+type layerPair struct {
+	a, b components.CollisionLayer
+}
+
+// collisionHandlers is the world resource holding every registered
+// response, keyed by the pair of layers it handles.
+type collisionHandlers struct {
+	byPair map[layerPair][]CollisionHandler
+}
+
+// OnCollision registers handler for collisions between colliders on layers
+// a and b. Systems for new kinds of entity register their own responses
+// here, typically in their constructor. Several handlers may share a pair;
+// they run in registration order until one of them destroys either entity.
+func OnCollision(world *ecs.World, a, b components.CollisionLayer, handler CollisionHandler) {
+	handlers := collisionHandlersOf(world)
+	key := layerPair{a, b}
+	handlers.byPair[key] = append(handlers.byPair[key], handler)
+}
+
+func collisionHandlersOf(world *ecs.World) *collisionHandlers {
+	handlers, ok := ecs.LookupResource[*collisionHandlers](world)
+	if !ok {
+		handlers = &collisionHandlers{byPair: make(map[layerPair][]CollisionHandler)}
+		ecs.SetResource(world, handlers)
+	}
+	return handlers
+}
+
+// dispatch calls the handlers registered for the contacts' layers in
+// either order.
+func (h *collisionHandlers) dispatch(commands *ecs.Commands, c1, c2 Contact) {
+	h.call(commands, h.byPair[layerPair{c1.Collider.Layer, c2.Collider.Layer}], c1, c2)
+	if c1.Collider.Layer != c2.Collider.Layer {
+		h.call(commands, h.byPair[layerPair{c2.Collider.Layer, c1.Collider.Layer}], c2, c1)
+	}
+}
+
+func (h *collisionHandlers) call(commands *ecs.Commands, handlers []CollisionHandler, a, b Contact) {
+	for _, handler := range handlers {
+		if commands.Destroyed(a.ID) || commands.Destroyed(b.ID) {
+			return
+		}
+		handler(a, b)
+	}
+}
+
+// collides reports whether each collider's mask accepts the other's layer.
+func collides(a, b components.Collider) bool {
+	return a.Mask.Has(b.Layer) && b.Mask.Has(a.Layer)
+}
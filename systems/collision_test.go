@@ -0,0 +1,49 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/systems"
+)
+
+// populateColliders fills a world with n colliders scattered over the
+// screen, mostly bullet and particle sized with some asteroid sized ones
+// mixed in. They are all bullets, which have no response to each other, so
+// every frame does the same work, while the mask still sends every overlap
+// through the narrowphase.
+func populateColliders(n int) *ecs.World {
+	world := game.NewWorld(1)
+	screen := game.ScreenOf(world)
+	rng := game.RandOf(world)
+	radii := []float64{2, 2, 2, 2, 2, 2, 10, 10, 20, 40}
+
+	for i := 0; i < n; i++ {
+		id := world.CreateEntity()
+		ecs.Set(world, id, components.Position{
+			X: rng.Float64() * float64(screen.Width()),
+			Y: rng.Float64() * float64(screen.Height()),
+		})
+		ecs.Set(world, id, components.Collider{
+			Layer:  components.LayerBullet,
+			Mask:   components.LayerBullet,
+			Radius: radii[rng.Intn(len(radii))],
+		})
+	}
+	return world
+}
+
+func benchmarkCollision(b *testing.B, n int) {
+	world := populateColliders(n)
+	collision := systems.NewCollisionSystem(world)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		collision.Update(ecs.DefaultStep)
+	}
+}
+
+func BenchmarkCollision1k(b *testing.B)  { benchmarkCollision(b, 1000) }
+func BenchmarkCollision10k(b *testing.B) { benchmarkCollision(b, 10000) }
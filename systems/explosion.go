@@ -3,29 +3,48 @@ package systems
 import (
 	"github.com/bobbyhiddn/ecs-asteroids/components"
 	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
 )
 
 type ExplosionSystem struct {
-	world *ecs.World
+	world      *ecs.World
+	explosions *ecs.Store[components.Explosion]
 }
 
 func NewExplosionSystem(world *ecs.World) *ExplosionSystem {
-	return &ExplosionSystem{world: world}
+	s := &ExplosionSystem{
+		world:      world,
+		explosions: ecs.Register[components.Explosion](world),
+	}
+	ecs.Subscribe(world, s.onAsteroidDestroyed)
+	ecs.Subscribe(world, s.onShipHit)
+	ecs.Subscribe(world, s.onSaucerDestroyed)
+	return s
 }
 
-func (s *ExplosionSystem) Update(dt float64) {
-	explosions := s.world.Components["components.Explosion"]
+func (s *ExplosionSystem) onAsteroidDestroyed(e events.AsteroidDestroyed) {
+	game.CreateExplosion(s.world, e.X, e.Y, float64(20+e.Size*10))
+}
+
+func (s *ExplosionSystem) onShipHit(e events.ShipHit) {
+	game.CreateExplosion(s.world, e.X, e.Y, 30.0) // Size matches ship roughly
+}
 
-	for id, explosionInterface := range explosions {
-		explosion := explosionInterface.(components.Explosion)
+func (s *ExplosionSystem) onSaucerDestroyed(e events.SaucerDestroyed) {
+	game.CreateExplosion(s.world, e.X, e.Y, 25.0)
+}
+
+func (s *ExplosionSystem) Update(dt float64) {
+	s.explosions.Each(func(id ecs.EntityID, explosion components.Explosion) {
 		explosion.Age += dt
 
 		if explosion.Age >= explosion.MaxAge {
-			s.world.DestroyEntity(id)
-			continue
+			s.world.Commands().Destroy(id)
+			return
 		}
 
 		// Update explosion
-		s.world.AddComponent(id, explosion)
-	}
+		s.explosions.Set(id, explosion)
+	})
 }
@@ -0,0 +1,69 @@
+package systems_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/systems"
+)
+
+const headlessFrames = 5000
+
+// scriptedInput flies the ship around, firing and jumping to hyperspace
+// now and then, and restarts the game whenever it ends.
+func scriptedInput() systems.InputSource {
+	frame := 0
+	return systems.InputFunc(func() systems.InputState {
+		frame++
+		return systems.InputState{
+			Left:       frame%240 < 60,
+			Right:      frame%240 >= 180,
+			Thrust:     frame%90 < 30,
+			Fire:       frame%12 == 0,
+			FireHeld:   frame%12 < 6,
+			Hyperspace: frame%600 == 0,
+			AnyPressed: frame%120 == 0,
+		}
+	})
+}
+
+// runHeadless steps a world with no window for the given number of frames
+// and returns its final state.
+func runHeadless(t *testing.T, seed int64, source systems.InputSource) []byte {
+	t.Helper()
+
+	world := game.NewWorld(seed)
+	systems.AddGameSystems(world, source)
+	game.StartGame(world)
+
+	for i := 0; i < headlessFrames; i++ {
+		world.Step()
+	}
+
+	if got := world.Clock().Frame(); got != headlessFrames {
+		t.Fatalf("clock is at frame %d after %d steps", got, headlessFrames)
+	}
+	data, err := world.SaveJSON()
+	if err != nil {
+		t.Fatalf("saving world: %v", err)
+	}
+	return data
+}
+
+func TestHeadlessIdle(t *testing.T) {
+	runHeadless(t, 1, systems.IdleInput{})
+}
+
+func TestHeadlessDeterministic(t *testing.T) {
+	first := runHeadless(t, 42, scriptedInput())
+	second := runHeadless(t, 42, scriptedInput())
+	if !bytes.Equal(first, second) {
+		t.Fatal("two runs with the same seed and input ended in different states")
+	}
+
+	other := runHeadless(t, 43, scriptedInput())
+	if bytes.Equal(first, other) {
+		t.Fatal("runs with different seeds ended in the same state")
+	}
+}
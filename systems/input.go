@@ -3,181 +3,124 @@ package systems
 import (
 	"fmt"
 	"math"
-	"math/rand"
 
 	"github.com/bobbyhiddn/ecs-asteroids/components"
 	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
 	"github.com/bobbyhiddn/ecs-asteroids/game"
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 type InputSystem struct {
-	world  *ecs.World
-	screen *game.Screen
+	world      *ecs.World
+	source     InputSource
+	screen     *game.Screen
+	players    *ecs.Store[components.Player]
+	inputs     *ecs.Store[components.Input]
+	positions  *ecs.Store[components.Position]
+	controlled *ecs.Query
 }
 
-func NewInputSystem(world *ecs.World) *InputSystem {
-	return &InputSystem{
-		world:  world,
-		screen: game.NewScreen(),
+func NewInputSystem(world *ecs.World, source InputSource) *InputSystem {
+	s := &InputSystem{
+		world:     world,
+		source:    source,
+		screen:    game.ScreenOf(world),
+		players:   ecs.Register[components.Player](world),
+		inputs:    ecs.Register[components.Input](world),
+		positions: ecs.Register[components.Position](world),
 	}
+	s.controlled = ecs.NewQuery(s.players, s.inputs)
+	return s
 }
 
 func (s *InputSystem) Update(dt float64) {
-	players := s.world.Components["components.Player"]
-	inputs := s.world.Components["components.Input"]
+	state := s.source.Poll()
 
-	for id, playerInterface := range players {
-		player := playerInterface.(components.Player)
-		input := inputs[id].(components.Input)
+	s.controlled.Each(func(id ecs.EntityID) {
+		player, _ := s.players.Get(id)
+		input, _ := s.inputs.Get(id)
 
 		// Check for game over restart
 		if player.IsGameOver {
 			// Check for any key press or new touch/click
-			if len(inpututil.AppendPressedKeys(nil)) > 0 ||
-				len(inpututil.AppendJustPressedTouchIDs(nil)) > 0 ||
-				inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			if state.AnyPressed {
 				fmt.Printf("Input detected during game over, restarting...\n")
-				s.handleGameRestart(id, &player)
-				continue
+				s.handleGameRestart(id)
+				return
 			}
-			continue // Skip other input processing when game over
+			return // Skip other input processing when game over
 		}
 
-		// Reset input state
+		// Reset input state. Shoot and Hyperspace are left latched until
+		// WeaponSystem and PlayerSystem consume them, so a press is never
+		// lost on a frame where the simulation doesn't step.
 		input.Rotate = 0
+		input.Steer = false
 		input.Forward = false
-		input.Shoot = false
+		input.Reverse = false
 		input.MousePressed = false
-
-		// Process multitouch inputs
-		touchIDs := ebiten.TouchIDs()
-		justPressedTouchIDs := inpututil.AppendJustPressedTouchIDs(nil)
-
-		for _, touchID := range touchIDs {
-			x, y := ebiten.TouchPosition(touchID)
-			input.MouseX = x
-			input.MouseY = y
+		input.ShootHeld = state.FireHeld
+
+		// Process touch and mouse input. A new touch while another finger is
+		// already steering is a two-finger tap, which jumps to hyperspace.
+		steering := 0
+		for _, p := range state.Pointers {
+			input.MouseX = p.X
+			input.MouseY = p.Y
 			input.MousePressed = true
 
-			// Check if touch is within the fire button area
+			// Check if the pointer is within the fire button area
 			fireButtonY := s.screen.Height() - 100
-			if x >= 20 && x <= 180 && y >= fireButtonY-80 && y <= fireButtonY+80 {
-				// Only shoot if this is a new touch
-				for _, justPressedID := range justPressedTouchIDs {
-					if touchID == justPressedID {
-						input.Shoot = true
-						break
-					}
-				}
+			if p.X >= 20 && p.X <= 180 && p.Y >= fireButtonY-80 && p.Y <= fireButtonY+80 {
+				// Only shoot on a new press
+				input.Shoot = input.Shoot || p.JustPressed
+				input.ShootHeld = true
 			} else {
 				// Process directional input
-				s.processDirectionalInput(id, float64(x), float64(y), &input)
-			}
-		}
-
-		// Handle mouse input for desktop testing
-		if len(touchIDs) == 0 {
-			if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-				x, y := ebiten.CursorPosition()
-				input.MouseX = x
-				input.MouseY = y
-				input.MousePressed = true
-
-				fireButtonY := s.screen.Height() - 100
-				if x >= 20 && x <= 180 && y >= fireButtonY-80 && y <= fireButtonY+80 {
-					// Only shoot if mouse button was just pressed
-					input.Shoot = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
-				} else {
-					s.processDirectionalInput(id, float64(x), float64(y), &input)
-				}
+				s.processDirectionalInput(id, float64(p.X), float64(p.Y), &input)
+				steering++
+				input.Hyperspace = input.Hyperspace || (steering > 1 && p.JustPressed)
 			}
 		}
 
 		// Handle keyboard input
-		if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
+		if state.Left {
 			input.Rotate = -1
 		}
-		if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
+		if state.Right {
 			input.Rotate = 1
 		}
-		if ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
+		if state.Thrust {
 			input.Forward = true
 		}
-		input.Shoot = input.Shoot || inpututil.IsKeyJustPressed(ebiten.KeySpace)
+		if state.Reverse {
+			input.Reverse = true
+		}
+		input.Shoot = input.Shoot || state.Fire
+		input.Hyperspace = input.Hyperspace || state.Hyperspace
 
 		// Update input component
-		s.world.AddComponent(id, input)
-	}
+		s.inputs.Set(id, input)
+	})
 }
 
+// processDirectionalInput steers the ship towards the pointer and thrusts.
+// PlayerSystem turns it onto the heading with its flight model.
 func (s *InputSystem) processDirectionalInput(id ecs.EntityID, x, y float64, input *components.Input) {
-	if pos, ok := s.world.Components["components.Position"][id].(components.Position); ok {
-		dx := x - pos.X
-		dy := y - pos.Y
-		targetAngle := math.Atan2(dy, dx)
-
-		if rot, ok := s.world.Components["components.Rotation"][id].(components.Rotation); ok {
-			diff := math.Mod(targetAngle-rot.Angle+math.Pi, 2*math.Pi) - math.Pi
-			if math.Abs(diff) > 0.1 {
-				if diff > 0 {
-					input.Rotate = 1
-				} else {
-					input.Rotate = -1
-				}
-			}
-		}
+	if pos, ok := s.positions.Get(id); ok {
+		input.Heading = math.Atan2(y-pos.Y, x-pos.X)
+		input.Steer = true
 		input.Forward = true
 	}
 }
 
-func (s *InputSystem) handleGameRestart(id ecs.EntityID, player *components.Player) {
-	// Reset player
-	player.Lives = 3
-	player.Score = 0
-	player.IsGameOver = false
-	s.world.AddComponent(id, *player)
-
-	// Add position component
-	s.world.AddComponent(id, components.Position{
-		X: float64(s.screen.Width() / 2),
-		Y: float64(s.screen.Height() / 2),
-	})
-
-	// Add velocity component
-	s.world.AddComponent(id, components.Velocity{
-		DX:       0,
-		DY:       0,
-		MaxSpeed: 400,
-	})
-
-	// Add rotation component
-	s.world.AddComponent(id, components.Rotation{
-		Angle: 0,
-	})
-
-	// Add renderable component
-	s.world.AddComponent(id, components.Renderable{
-		Visible: true,
-	})
-
-	// Add collider component
-	s.world.AddComponent(id, components.Collider{
-		Type:   components.ColliderTypeShip,
-		Radius: 20,
-	})
-
-	// Add input component
-	s.world.AddComponent(id, components.Input{})
-
-	// Clear all asteroids
-	for asteroidID := range s.world.Components["components.Asteroid"] {
-		s.world.DestroyEntity(asteroidID)
+func (s *InputSystem) handleGameRestart(id ecs.EntityID) {
+	err := game.ResetPlayerShip(s.world, id, s.screen.CenterX(), s.screen.CenterY())
+	if err != nil {
+		fmt.Printf("Restart failed: %v\n", err)
+		return
 	}
 
-	// Create initial asteroids
-	for i := 0; i < 4; i++ {
-		game.CreateAsteroid(s.world, rand.Intn(3))
-	}
+	// The wave system clears the field and starts over from the first wave
+	ecs.Publish(s.world, events.GameRestarted{Player: id})
 }
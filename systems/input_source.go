@@ -0,0 +1,43 @@
+package systems
+
+// Pointer is a touch or held mouse button at a screen position.
+type Pointer struct {
+	X, Y        int
+	JustPressed bool // Went down this frame
+}
+
+// InputState is one frame's worth of raw controls.
+type InputState struct {
+	Left, Right bool
+	Thrust      bool
+	Reverse     bool
+	Fire        bool      // Fire was pressed this frame
+	FireHeld    bool      // Fire is held down
+	Hyperspace  bool      // Hyperspace was pressed this frame
+	AnyPressed  bool      // A key is held or a click or touch began, used to restart
+	Pointers    []Pointer // Active touches, or the mouse when nothing touches
+}
+
+// InputSource supplies the player's controls to InputSystem. The Ebiten
+// frontend reads the keyboard, mouse and touch screen; headless runs use
+// IdleInput or a scripted source.
+type InputSource interface {
+	Poll() InputState
+}
+
+// IdleInput is an InputSource that never presses anything.
+type IdleInput struct{}
+
+// Poll implements InputSource.
+func (IdleInput) Poll() InputState {
+	return InputState{}
+}
+
+// InputFunc adapts a function to an InputSource, which is handy for scripted
+// runs.
+type InputFunc func() InputState
+
+// Poll implements InputSource.
+func (f InputFunc) Poll() InputState {
+	return f()
+}
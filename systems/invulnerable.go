@@ -6,41 +6,42 @@ import (
 )
 
 type InvulnerableSystem struct {
-	world *ecs.World
+	world         *ecs.World
+	invulnerables *ecs.Store[components.Invulnerable]
+	renderables   *ecs.Store[components.Renderable]
 }
 
 func NewInvulnerableSystem(world *ecs.World) *InvulnerableSystem {
-	return &InvulnerableSystem{world: world}
+	return &InvulnerableSystem{
+		world:         world,
+		invulnerables: ecs.Register[components.Invulnerable](world),
+		renderables:   ecs.Register[components.Renderable](world),
+	}
 }
 
 func (s *InvulnerableSystem) Update(dt float64) {
-	invulnerables := s.world.Components["components.Invulnerable"]
-	renderables := s.world.Components["components.Renderable"]
-
-	for id, invulnerableInterface := range invulnerables {
-		invulnerable := invulnerableInterface.(components.Invulnerable)
-
+	s.invulnerables.Each(func(id ecs.EntityID, invulnerable components.Invulnerable) {
 		// Update timer
 		invulnerable.Timer -= dt
 
 		// Handle blinking effect
-		if renderable, ok := renderables[id].(components.Renderable); ok {
+		if renderable, ok := s.renderables.Get(id); ok {
 			// Blink 4 times per second
 			renderable.Visible = int(invulnerable.Timer*4)%2 == 0
-			s.world.AddComponent(id, renderable)
+			s.renderables.Set(id, renderable)
 		}
 
 		// Remove invulnerability when timer expires
 		if invulnerable.Timer <= 0 {
-			s.world.RemoveComponent(id, "components.Invulnerable")
+			s.invulnerables.Remove(id)
 			// Make sure ship is visible when invulnerability ends
-			if renderable, ok := renderables[id].(components.Renderable); ok {
+			if renderable, ok := s.renderables.Get(id); ok {
 				renderable.Visible = true
-				s.world.AddComponent(id, renderable)
+				s.renderables.Set(id, renderable)
 			}
-			continue
+			return
 		}
 
-		s.world.AddComponent(id, invulnerable)
-	}
+		s.invulnerables.Set(id, invulnerable)
+	})
 }
@@ -0,0 +1,27 @@
+package systems
+
+import (
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+)
+
+type LifetimeSystem struct {
+	world     *ecs.World
+	lifetimes *ecs.Store[components.Lifetime]
+}
+
+func NewLifetimeSystem(world *ecs.World) *LifetimeSystem {
+	return &LifetimeSystem{
+		world:     world,
+		lifetimes: ecs.Register[components.Lifetime](world),
+	}
+}
+
+func (s *LifetimeSystem) Update(dt float64) {
+	now := s.world.Clock().Now()
+	s.lifetimes.Each(func(id ecs.EntityID, lifetime components.Lifetime) {
+		if now-lifetime.Created >= lifetime.Duration {
+			s.world.Commands().Destroy(id)
+		}
+	})
+}
@@ -7,57 +7,73 @@ import (
 )
 
 type MovementSystem struct {
-	world  *ecs.World
-	screen *game.Screen
+	world      *ecs.World
+	screen     *game.Screen
+	positions  *ecs.Store[components.Position]
+	velocities *ecs.Store[components.Velocity]
+	rotations  *ecs.Store[components.Rotation]
+	wraps      *ecs.Store[components.ScreenWrap]
+	previous   *ecs.Store[components.PreviousTransform]
+	moving     *ecs.Query
 }
 
 func NewMovementSystem(world *ecs.World) *MovementSystem {
-	return &MovementSystem{
-		world:  world,
-		screen: game.NewScreen(),
+	s := &MovementSystem{
+		world:      world,
+		screen:     game.ScreenOf(world),
+		positions:  ecs.Register[components.Position](world),
+		velocities: ecs.Register[components.Velocity](world),
+		rotations:  ecs.Register[components.Rotation](world),
+		wraps:      ecs.Register[components.ScreenWrap](world),
+		previous:   ecs.Register[components.PreviousTransform](world),
 	}
+	s.moving = ecs.NewQuery(s.positions)
+	return s
 }
 
 func (s *MovementSystem) Update(dt float64) {
-	positions := s.world.Components["components.Position"]
-	velocities := s.world.Components["components.Velocity"]
-	rotations := s.world.Components["components.Rotation"]
-
-	for id := range positions {
-		pos := positions[id].(components.Position)
+	s.moving.Each(func(id ecs.EntityID) {
+		pos, _ := s.positions.Get(id)
 		positionUpdated := false
 
+		// Remember where the entity was so rendering can interpolate
+		prev := components.PreviousTransform{X: pos.X, Y: pos.Y}
+		if rot, ok := s.rotations.Get(id); ok {
+			prev.Angle = rot.Angle
+		}
+		s.previous.Set(id, prev)
+
 		// Update position based on velocity if entity has one
-		if vel, ok := velocities[id].(components.Velocity); ok {
+		if vel, ok := s.velocities.Get(id); ok {
 			pos.X += vel.DX * dt
 			pos.Y += vel.DY * dt
 			positionUpdated = true
 		}
 
 		// Update rotation if entity has one
-		if rot, ok := rotations[id].(components.Rotation); ok {
+		if rot, ok := s.rotations.Get(id); ok {
 			rot.Angle += rot.RotationSpeed * dt
-			s.world.AddComponent(id, rot)
+			s.rotations.Set(id, rot)
 		}
 
 		// Handle screen wrapping or off-screen destruction
-		if _, isPlayer := s.world.Components["components.Player"][id]; isPlayer {
-			// Wrap player position around screen edges
+		if s.wraps.Has(id) {
+			// Wrap position around screen edges
 			s.wrapPosition(&pos)
 			positionUpdated = true
 		} else {
-			// For non-player entities, destroy them if they go too far off screen
+			// Anything else is destroyed once it goes off screen
 			if s.isOffScreen(pos) {
-				s.world.DestroyEntity(id)
-				continue
+				s.world.Commands().Destroy(id)
+				return
 			}
 		}
 
 		// Save the updated position if it changed
 		if positionUpdated {
-			s.world.AddComponent(id, pos)
+			s.positions.Set(id, pos)
 		}
-	}
+	})
 }
 
 func (s *MovementSystem) wrapPosition(pos *components.Position) {
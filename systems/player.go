@@ -5,76 +5,183 @@ import (
 
 	"github.com/bobbyhiddn/ecs-asteroids/components"
 	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
 	"github.com/bobbyhiddn/ecs-asteroids/game"
 )
 
-const (
-	thrustForce   = 250.0
-	rotationSpeed = 0.1
-)
-
 type PlayerSystem struct {
-	world *ecs.World
+	world      *ecs.World
+	players    *ecs.Store[components.Player]
+	inputs     *ecs.Store[components.Input]
+	velocities *ecs.Store[components.Velocity]
+	rotations  *ecs.Store[components.Rotation]
+	positions  *ecs.Store[components.Position]
+	hyperspace *ecs.Store[components.Hyperspace]
+	flight     *ecs.Store[components.FlightModel]
+	controlled *ecs.Query
 }
 
 func NewPlayerSystem(world *ecs.World) *PlayerSystem {
-	return &PlayerSystem{world: world}
+	s := &PlayerSystem{
+		world:      world,
+		players:    ecs.Register[components.Player](world),
+		inputs:     ecs.Register[components.Input](world),
+		velocities: ecs.Register[components.Velocity](world),
+		rotations:  ecs.Register[components.Rotation](world),
+		positions:  ecs.Register[components.Position](world),
+		hyperspace: ecs.Register[components.Hyperspace](world),
+		flight:     ecs.Register[components.FlightModel](world),
+	}
+	s.controlled = ecs.NewQuery(s.players, s.inputs)
+	return s
 }
 
 func (s *PlayerSystem) Update(dt float64) {
-	players := s.world.Components["components.Player"]
-	inputs := s.world.Components["components.Input"]
-	velocities := s.world.Components["components.Velocity"]
-	rotations := s.world.Components["components.Rotation"]
-	positions := s.world.Components["components.Position"]
-
-	for id := range players {
-		if input, ok := inputs[id].(components.Input); ok {
-			// Handle rotation
-			if rot, ok := rotations[id].(components.Rotation); ok {
-				rot.Angle += float64(input.Rotate) * rotationSpeed
-				s.world.AddComponent(id, rot)
-			}
+	s.controlled.Each(func(id ecs.EntityID) {
+		player, _ := s.players.Get(id)
+		input, _ := s.inputs.Get(id)
 
-			// Handle thrust
-			if vel, ok := velocities[id].(components.Velocity); ok {
-				if input.Forward {
-					// Update player velocity based on current rotation
-					if rot, ok := rotations[id].(components.Rotation); ok {
-						// Apply thrust force scaled by dt
-						vel.DX += math.Cos(rot.Angle) * thrustForce * dt
-						vel.DY += math.Sin(rot.Angle) * thrustForce * dt
-					}
+		// A ship in hyperspace can't be flown until it comes back
+		if jump, ok := s.hyperspace.Get(id); ok {
+			if jump.ReturnAt > 0 {
+				s.updateHyperspace(id, jump)
+				return
+			}
+			if input.Hyperspace {
+				input.Hyperspace = false
+				s.inputs.Set(id, input)
+				if s.enterHyperspace(id, jump) {
+					return
 				}
+			}
+		}
 
-				// Apply velocity limits
-				speed := math.Sqrt(vel.DX*vel.DX + vel.DY*vel.DY)
-				if speed > vel.MaxSpeed {
-					scale := vel.MaxSpeed / speed
-					vel.DX *= scale
-					vel.DY *= scale
-				}
+		model, _ := s.flight.Get(id)
+
+		// Handle rotation. The ship's spin is what turns it; the movement
+		// system applies it to the angle.
+		rot, _ := s.rotations.Get(id)
+		turn := input.Rotate
+		if turn == 0 && input.Steer {
+			turn = steer(rot, input.Heading, model, dt)
+		}
+		switch {
+		case turn != 0:
+			rot.RotationSpeed += turn * model.TurnAcceleration * dt
+			rot.RotationSpeed = math.Max(-model.MaxTurnRate, math.Min(model.MaxTurnRate, rot.RotationSpeed))
+		case input.Rotate == 0 && !input.Steer:
+			// Damping only slows the ship once the player lets go; steering
+			// that holds the current spin keeps it
+			rot.RotationSpeed *= math.Exp(-model.TurnDamping * dt)
+		}
+		if s.rotations.Has(id) {
+			s.rotations.Set(id, rot)
+		}
 
-				s.world.AddComponent(id, vel)
+		// Handle thrust
+		if vel, ok := s.velocities.Get(id); ok {
+			// Accelerate along the way the ship faces, or brake against it
+			accel := 0.0
+			if input.Forward {
+				accel += model.Thrust
 			}
+			if input.Reverse {
+				accel -= model.ReverseThrust
+			}
+			vel.DX += math.Cos(rot.Angle) * accel * dt
+			vel.DY += math.Sin(rot.Angle) * accel * dt
 
-			// Update thruster visibility
-			player := players[id].(components.Player)
-			player.IsThrusting = input.Forward
-			s.world.AddComponent(id, player)
-
-			// Handle shooting
-			if input.Shoot {
-				if pos, ok := positions[id].(components.Position); ok {
-					if rot, ok := rotations[id].(components.Rotation); ok {
-						// Create bullet at ship's position with ship's rotation
-						game.CreateBullet(s.world, pos.X, pos.Y, rot.Angle, id)
-					}
-				}
-				// Reset shoot flag
-				input.Shoot = false
-				s.world.AddComponent(id, input)
+			// Drag bleeds off speed whether or not the ship is thrusting
+			decay := math.Exp(-model.Drag * dt)
+			vel.DX *= decay
+			vel.DY *= decay
+
+			// Apply velocity limits
+			speed := math.Sqrt(vel.DX*vel.DX + vel.DY*vel.DY)
+			if speed > vel.MaxSpeed {
+				scale := vel.MaxSpeed / speed
+				vel.DX *= scale
+				vel.DY *= scale
 			}
+
+			s.velocities.Set(id, vel)
 		}
+
+		// Update thruster visibility
+		player.IsThrusting = input.Forward
+		s.players.Set(id, player)
+	})
+}
+
+// steer returns how hard to turn, from -1 to 1, to bring the ship round to
+// heading. It turns as fast as it can while it can still slow down in time,
+// so it comes to rest on the heading rather than swinging past it.
+func steer(rot components.Rotation, heading float64, model components.FlightModel, dt float64) float64 {
+	if model.TurnAcceleration <= 0 || dt <= 0 {
+		return 0
+	}
+	diff := math.Remainder(heading-rot.Angle, 2*math.Pi)
+
+	// The fastest turn rate that, after this step, can still brake to a
+	// stop on the heading
+	a := model.TurnAcceleration
+	want := math.Sqrt(a*a*dt*dt+2*a*math.Abs(diff)) - a*dt
+	want = math.Copysign(math.Min(want, model.MaxTurnRate), diff)
+
+	turn := (want - rot.RotationSpeed) / (model.TurnAcceleration * dt)
+	return math.Max(-1, math.Min(1, turn))
+}
+
+// enterHyperspace takes the ship off the screen if its jump is ready. It
+// has no position while it is away, so nothing can hit it, draw it or
+// target it.
+func (s *PlayerSystem) enterHyperspace(id ecs.EntityID, jump components.Hyperspace) bool {
+	now := s.world.Clock().Now()
+	pos, ok := s.positions.Get(id)
+	if !ok || now < jump.ReadyAt {
+		return false
+	}
+
+	jump.ReturnAt = now + jump.Delay
+	s.hyperspace.Set(id, jump)
+	s.positions.Remove(id)
+
+	player, _ := s.players.Get(id)
+	player.IsThrusting = false
+	s.players.Set(id, player)
+
+	ecs.Publish(s.world, events.HyperspaceEntered{Ship: id, X: pos.X, Y: pos.Y})
+	return true
+}
+
+// updateHyperspace brings the ship back at a random spot once its time is
+// up. Re-entry may fail and destroy it; landing inside an asteroid is left
+// to the collision pass, which sees the ship straight away.
+func (s *PlayerSystem) updateHyperspace(id ecs.EntityID, jump components.Hyperspace) {
+	now := s.world.Clock().Now()
+	if now < jump.ReturnAt {
+		return
+	}
+
+	rng := game.RandOf(s.world)
+	screen := game.ScreenOf(s.world)
+	pos := components.Position{
+		X: rng.Float64() * float64(screen.Width()),
+		Y: rng.Float64() * float64(screen.Height()),
 	}
+	s.positions.Set(id, pos)
+
+	// It comes out at a standstill
+	if vel, ok := s.velocities.Get(id); ok {
+		vel.DX, vel.DY = 0, 0
+		s.velocities.Set(id, vel)
+	}
+
+	jump.ReturnAt = 0
+	jump.ReadyAt = now + jump.Cooldown
+	s.hyperspace.Set(id, jump)
+
+	// A ship still invulnerable from respawning survives a failed re-entry
+	destroyed := rng.Float64() < jump.Risk && destroyShip(s.world, id)
+	ecs.Publish(s.world, events.HyperspaceExited{Ship: id, X: pos.X, Y: pos.Y, Destroyed: destroyed})
 }
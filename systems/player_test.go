@@ -0,0 +1,48 @@
+package systems_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/systems"
+)
+
+// TestSteerHoldsMaxTurnRate steers towards a heading that stays well ahead
+// of the ship, so once it is up to speed it should turn at its top rate
+// every step rather than slowing between steps.
+func TestSteerHoldsMaxTurnRate(t *testing.T) {
+	for name, model := range game.FlightPresets {
+		t.Run(name, func(t *testing.T) {
+			world := game.NewWorld(1)
+			player := systems.NewPlayerSystem(world)
+
+			id := world.CreateEntity()
+			ecs.Set(world, id, components.Player{Lives: 3})
+			ecs.Set(world, id, components.Rotation{})
+			ecs.Set(world, id, model)
+
+			const (
+				frames = 180
+				ahead  = 2.0 // Radians between the ship and the heading
+			)
+			// Time to reach the top rate from rest, with a step to spare
+			warmUp := int(math.Ceil(model.MaxTurnRate/model.TurnAcceleration/ecs.DefaultStep)) + 1
+
+			for frame := 0; frame < frames; frame++ {
+				rot, _ := ecs.Get[components.Rotation](world, id)
+				ecs.Set(world, id, components.Input{Steer: true, Heading: rot.Angle + ahead})
+				player.Update(ecs.DefaultStep)
+
+				rot, _ = ecs.Get[components.Rotation](world, id)
+				if frame >= warmUp && math.Abs(rot.RotationSpeed-model.MaxTurnRate) > 1e-9 {
+					t.Fatalf("frame %d: turning at %v, want %v", frame, rot.RotationSpeed, model.MaxTurnRate)
+				}
+				rot.Angle += rot.RotationSpeed * ecs.DefaultStep
+				ecs.Set(world, id, rot)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package systems
+
+import (
+	"math"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+)
+
+// PowerUpSystem drops pickups from asteroids the player shoots, hands them
+// to the ship that collects them and counts down the effects they give.
+// WeaponSystem and CollisionSystem read the effects themselves.
+type PowerUpSystem struct {
+	world    *ecs.World
+	powerUps *ecs.Store[components.PowerUp]
+	effects  *ecs.Store[components.Effects]
+	players  *ecs.Store[components.Player]
+}
+
+func NewPowerUpSystem(world *ecs.World) *PowerUpSystem {
+	s := &PowerUpSystem{
+		world:    world,
+		powerUps: ecs.Register[components.PowerUp](world),
+		effects:  ecs.Register[components.Effects](world),
+		players:  ecs.Register[components.Player](world),
+	}
+
+	OnCollision(world, components.LayerShip, components.LayerPowerUp, func(ship, pickup Contact) {
+		s.collect(ship.ID, pickup.ID)
+	})
+	ecs.Subscribe(world, s.onAsteroidDestroyed)
+
+	// Power-ups are lost with the ship, and a new game starts without any
+	// lying around
+	ecs.Subscribe(world, func(e events.ShipHit) {
+		s.effects.Remove(e.Ship)
+	})
+	ecs.Subscribe(world, func(e events.GameRestarted) {
+		s.effects.Remove(e.Player)
+		s.powerUps.Each(func(id ecs.EntityID, _ components.PowerUp) {
+			world.Commands().Destroy(id)
+		})
+	})
+	return s
+}
+
+func (s *PowerUpSystem) Update(dt float64) {
+	s.effects.Each(func(id ecs.EntityID, effects components.Effects) {
+		var active []components.Effect
+		for _, effect := range effects.Active {
+			effect.Remaining -= dt
+			if effect.Remaining > 0 {
+				active = append(active, effect)
+			}
+		}
+
+		if len(active) == 0 {
+			s.effects.Remove(id)
+			return
+		}
+		effects.Active = active
+		s.effects.Set(id, effects)
+	})
+}
+
+// onAsteroidDestroyed sometimes leaves a pickup where a player shot an
+// asteroid. Asteroids broken up by collisions or saucers drop nothing.
+func (s *PowerUpSystem) onAsteroidDestroyed(e events.AsteroidDestroyed) {
+	if !s.players.Has(e.By) {
+		return
+	}
+
+	table := game.ConfigOf(s.world).PowerUps
+	rng := game.RandOf(s.world)
+	if rng.Float64() >= table.DropChance {
+		return
+	}
+	drop, ok := table.Pick(rng)
+	if !ok {
+		return
+	}
+
+	angle := rng.Float64() * 2 * math.Pi
+	s.world.Commands().Spawn(func(w *ecs.World) {
+		game.CreatePowerUp(w, drop.Kind, e.X, e.Y, angle, table.Speed)
+	})
+}
+
+func (s *PowerUpSystem) collect(shipID, pickupID ecs.EntityID) {
+	powerUp, ok := s.powerUps.Get(pickupID)
+	if !ok {
+		return
+	}
+	s.world.Commands().Destroy(pickupID)
+
+	if powerUp.Kind == components.PowerUpExtraLife {
+		maxLives := game.ConfigOf(s.world).ExtraLives.MaxLives
+		if player, ok := s.players.Get(shipID); ok && (maxLives <= 0 || player.Lives < maxLives) {
+			player.Lives++
+			s.players.Set(shipID, player)
+		}
+	} else {
+		effects, _ := s.effects.Get(shipID)
+		effects.Add(powerUp.Kind, game.ConfigOf(s.world).PowerUps.Duration(powerUp.Kind))
+		s.effects.Set(shipID, effects)
+	}
+
+	ecs.Publish(s.world, events.PowerUpCollected{Ship: shipID, Kind: powerUp.Kind})
+}
@@ -0,0 +1,271 @@
+package systems
+
+import (
+	"math"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+)
+
+// SaucerSystem sends a flying saucer across the screen every so often and
+// flies it: it changes course at random and shoots at the nearest ship. It
+// also handles what saucers and their bullets run into.
+type SaucerSystem struct {
+	world         *ecs.World
+	screen        *game.Screen
+	nextSpawnAt   float64 // When the next saucer arrives, 0 until one is due
+	saucers       *ecs.Store[components.Saucer]
+	positions     *ecs.Store[components.Position]
+	velocities    *ecs.Store[components.Velocity]
+	players       *ecs.Store[components.Player]
+	invulnerables *ecs.Store[components.Invulnerable]
+	ships         *ecs.Query
+}
+
+func NewSaucerSystem(world *ecs.World) *SaucerSystem {
+	s := &SaucerSystem{
+		world:         world,
+		screen:        game.ScreenOf(world),
+		saucers:       ecs.Register[components.Saucer](world),
+		positions:     ecs.Register[components.Position](world),
+		velocities:    ecs.Register[components.Velocity](world),
+		players:       ecs.Register[components.Player](world),
+		invulnerables: ecs.Register[components.Invulnerable](world),
+	}
+	s.ships = ecs.NewQuery(s.players, s.positions)
+
+	OnCollision(world, components.LayerBullet, components.LayerSaucer, func(bullet, saucer Contact) {
+		s.handleSaucerHit(saucer.ID, shooterOf(world, bullet.ID))
+		spendBullet(world, bullet.ID)
+	})
+	OnCollision(world, components.LayerShip, components.LayerSaucer, func(ship, saucer Contact) {
+		switch {
+		case shielded(world, ship.ID):
+			s.handleSaucerHit(saucer.ID, ship.ID)
+		case !s.invulnerables.Has(ship.ID):
+			// Ramming a saucer still earns its points
+			destroyShip(world, ship.ID)
+			s.handleSaucerHit(saucer.ID, ship.ID)
+		}
+	})
+	OnCollision(world, components.LayerSaucer, components.LayerAsteroid, func(saucer, asteroid Contact) {
+		breakAsteroid(world, asteroid.ID, ecs.NoEntity, impactOf(world, saucer))
+		s.handleSaucerHit(saucer.ID, ecs.NoEntity)
+	})
+
+	// The saucers' bullets break asteroids just like the player's, and
+	// bounce off a shield
+	OnCollision(world, components.LayerEnemyBullet, components.LayerAsteroid, func(bullet, asteroid Contact) {
+		breakAsteroid(world, asteroid.ID, ecs.NoEntity, impactOf(world, bullet))
+		spendBullet(world, bullet.ID)
+	})
+	OnCollision(world, components.LayerEnemyBullet, components.LayerShip, func(bullet, ship Contact) {
+		switch {
+		case shielded(world, ship.ID):
+			world.Commands().Destroy(bullet.ID)
+		case !s.invulnerables.Has(ship.ID):
+			destroyShip(world, ship.ID)
+			world.Commands().Destroy(bullet.ID)
+		}
+	})
+
+	ecs.Subscribe(world, func(e events.GameRestarted) {
+		s.nextSpawnAt = 0
+		s.saucers.Each(func(id ecs.EntityID, _ components.Saucer) {
+			world.Commands().Destroy(id)
+		})
+	})
+	return s
+}
+
+type saucerState struct {
+	NextSpawnAt float64 `json:"next_spawn_at"`
+}
+
+// SnapshotState implements ecs.Snapshotter.
+func (s *SaucerSystem) SnapshotState() any {
+	return saucerState{NextSpawnAt: s.nextSpawnAt}
+}
+
+// RestoreState implements ecs.Snapshotter.
+func (s *SaucerSystem) RestoreState(decode func(v any) error) error {
+	var state saucerState
+	if err := decode(&state); err != nil {
+		return err
+	}
+	s.nextSpawnAt = state.NextSpawnAt
+	return nil
+}
+
+func (s *SaucerSystem) Update(dt float64) {
+	now := s.world.Clock().Now()
+
+	if s.saucers.Len() > 0 {
+		s.saucers.Each(func(id ecs.EntityID, saucer components.Saucer) {
+			s.fly(id, saucer, now, dt)
+		})
+		return
+	}
+
+	// Only one saucer at a time; the next is due a while after the last
+	// one leaves
+	schedule := game.ConfigOf(s.world).Saucers
+	switch {
+	case s.nextSpawnAt == 0:
+		s.nextSpawnAt = now + schedule.IntervalAt(s.wave())
+	case now >= s.nextSpawnAt:
+		s.nextSpawnAt = 0
+		s.spawnSaucer(schedule)
+	}
+}
+
+func (s *SaucerSystem) fly(id ecs.EntityID, saucer components.Saucer, now, dt float64) {
+	pos, ok := s.positions.Get(id)
+	if !ok {
+		return
+	}
+	vel, _ := s.velocities.Get(id)
+
+	// Leave once it has crossed the whole screen
+	saucer.Travelled += math.Abs(vel.DX) * dt
+	if saucer.Travelled >= float64(s.screen.Width()) {
+		s.world.Commands().Destroy(id)
+		return
+	}
+
+	// Change course between flying straight and diagonally up or down,
+	// keeping the same heading across the screen
+	if now >= saucer.NextTurn {
+		rng := game.RandOf(s.world)
+		angle := float64(rng.Intn(3)-1) * math.Pi / 4
+		vel.DX = math.Copysign(math.Cos(angle)*vel.MaxSpeed, vel.DX)
+		vel.DY = math.Sin(angle) * vel.MaxSpeed
+		s.velocities.Set(id, vel)
+		saucer.NextTurn = now + saucer.TurnInterval
+	}
+
+	if now >= saucer.NextShot {
+		angle := s.aim(pos, saucer.Accuracy)
+		s.world.Commands().Spawn(func(w *ecs.World) {
+			game.CreateBulletFromPrefab(w, "saucer_bullet", pos.X, pos.Y, angle, id)
+		})
+		saucer.NextShot = now + saucer.FireInterval
+	}
+
+	s.saucers.Set(id, saucer)
+}
+
+// aim picks the angle to fire at from pos: towards where the nearest ship
+// will be when the bullet gets there, thrown off by up to half a turn
+// either way for a saucer with no accuracy at all.
+func (s *SaucerSystem) aim(pos components.Position, accuracy float64) float64 {
+	rng := game.RandOf(s.world)
+	spread := (1 - accuracy) * math.Pi * (2*rng.Float64() - 1)
+
+	target, ok := s.nearestShip(pos)
+	if !ok {
+		return rng.Float64() * 2 * math.Pi
+	}
+	targetPos, _ := s.positions.Get(target)
+	targetVel, _ := s.velocities.Get(target)
+	bullet, _ := game.PrefabComponent[components.Velocity]("saucer_bullet")
+
+	dx, dy := s.screen.WrapDelta(targetPos.X-pos.X, targetPos.Y-pos.Y)
+	return leadAngle(dx, dy, targetVel.DX, targetVel.DY, bullet.MaxSpeed) + spread
+}
+
+// leadAngle returns the direction to fire a bullet at speed so it meets a
+// target at offset (dx, dy) moving at (vx, vy). When the bullet can't catch
+// the target it fires straight at it.
+func leadAngle(dx, dy, vx, vy, speed float64) float64 {
+	// Solve |d + v*t| = speed*t for the earliest t > 0
+	a := vx*vx + vy*vy - speed*speed
+	b := 2 * (dx*vx + dy*vy)
+	c := dx*dx + dy*dy
+
+	t := -1.0
+	if math.Abs(a) < 1e-9 {
+		if b != 0 {
+			t = -c / b
+		}
+	} else if disc := b*b - 4*a*c; disc >= 0 {
+		root := math.Sqrt(disc)
+		t1, t2 := (-b-root)/(2*a), (-b+root)/(2*a)
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		t = t1
+		if t <= 0 {
+			t = t2
+		}
+	}
+
+	if t > 0 {
+		dx += vx * t
+		dy += vy * t
+	}
+	return math.Atan2(dy, dx)
+}
+
+func (s *SaucerSystem) nearestShip(pos components.Position) (ecs.EntityID, bool) {
+	nearest, found := ecs.NoEntity, false
+	best := math.Inf(1)
+	s.ships.Each(func(id ecs.EntityID) {
+		shipPos, _ := s.positions.Get(id)
+		dx, dy := s.screen.WrapDelta(shipPos.X-pos.X, shipPos.Y-pos.Y)
+		if d := math.Hypot(dx, dy); d < best {
+			nearest, found, best = id, true, d
+		}
+	})
+	return nearest, found
+}
+
+// wave returns the furthest wave any player has reached.
+func (s *SaucerSystem) wave() int {
+	wave := 1
+	s.players.Each(func(id ecs.EntityID, player components.Player) {
+		wave = max(wave, player.Wave)
+	})
+	return wave
+}
+
+func (s *SaucerSystem) spawnSaucer(schedule game.SaucerSchedule) {
+	rng := game.RandOf(s.world)
+
+	prefab := "saucer_large"
+	if rng.Float64() < schedule.SmallChanceAt(s.wave()) {
+		prefab = "saucer_small"
+	}
+
+	// The left and right edges are the same line on the wrapping
+	// playfield, so either heading starts there
+	y := float64(s.screen.Height()) * (0.1 + 0.8*rng.Float64())
+	dir := 1.0
+	if rng.Intn(2) == 0 {
+		dir = -1
+	}
+
+	s.world.Commands().Spawn(func(w *ecs.World) {
+		game.CreateSaucer(w, prefab, 0, y, dir)
+	})
+}
+
+// handleSaucerHit destroys a saucer that was hit, crediting by.
+func (s *SaucerSystem) handleSaucerHit(saucerID, by ecs.EntityID) {
+	saucer, ok := s.saucers.Get(saucerID)
+	if !ok {
+		return
+	}
+	pos, _ := s.positions.Get(saucerID)
+
+	ecs.Publish(s.world, events.SaucerDestroyed{
+		Saucer: saucerID,
+		Points: saucer.Points,
+		X:      pos.X,
+		Y:      pos.Y,
+		By:     by,
+	})
+	s.world.Commands().Destroy(saucerID)
+}
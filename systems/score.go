@@ -3,34 +3,88 @@ package systems
 import (
 	"github.com/bobbyhiddn/ecs-asteroids/components"
 	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
 	"github.com/bobbyhiddn/ecs-asteroids/highscore"
 )
 
 type ScoreSystem struct {
-	world      *ecs.World
-	highScores *highscore.HighScores
+	world   *ecs.World
+	players *ecs.Store[components.Player]
 }
 
 func NewScoreSystem(world *ecs.World) *ScoreSystem {
-	return &ScoreSystem{
-		world:      world,
-		highScores: highscore.GetInstance(),
+	s := &ScoreSystem{
+		world:   world,
+		players: ecs.Register[components.Player](world),
 	}
+	ecs.Subscribe(world, s.onAsteroidDestroyed)
+	ecs.Subscribe(world, s.onSaucerDestroyed)
+	ecs.Subscribe(world, s.onGameOver)
+	return s
 }
 
 func (s *ScoreSystem) Update(dt float64) {
-	players := s.world.Components["components.Player"]
-	for _, playerInterface := range players {
-		player := playerInterface.(components.Player)
-		if player.IsGameOver {
-			// When game is over, check and save high score
-			if s.highScores.IsHighScore(player.Score) {
-				s.highScores.AddScore(player.Score)
+	// Scoring is driven entirely by events
+}
+
+func (s *ScoreSystem) onAsteroidDestroyed(e events.AsteroidDestroyed) {
+	// Award points based on asteroid size
+	switch e.Size {
+	case 0: // Small
+		s.addScore(e.By, 100)
+	case 1: // Medium
+		s.addScore(e.By, 50)
+	case 2: // Large
+		s.addScore(e.By, 20)
+	}
+}
+
+func (s *ScoreSystem) onSaucerDestroyed(e events.SaucerDestroyed) {
+	s.addScore(e.By, e.Points)
+}
+
+// addScore gives a player points, along with any extra lives the ruleset
+// awards for the thresholds they pass.
+func (s *ScoreSystem) addScore(id ecs.EntityID, points int) {
+	player, ok := s.players.Get(id)
+	if !ok {
+		return
+	}
+
+	before := player.Score
+	player.Score += points
+
+	if !player.IsGameOver {
+		rule := game.ConfigOf(s.world).ExtraLives
+		for i := rule.Earned(before, player.Score); i > 0; i-- {
+			if rule.MaxLives > 0 && player.Lives >= rule.MaxLives {
+				break
 			}
+			player.Lives++
+			ecs.Publish(s.world, events.ExtraLife{Player: id, Lives: player.Lives, Score: player.Score})
 		}
 	}
+
+	// Update player score
+	s.players.Set(id, player)
 }
 
-func (s *ScoreSystem) GetTopScores() []highscore.Score {
-	return s.highScores.GetTopScores()
+func (s *ScoreSystem) onGameOver(e events.GameOver) {
+	// Read the score from the player rather than the event so points from
+	// asteroids destroyed in the same frame are included
+	score, wave := e.Score, 0
+	if player, ok := s.players.Get(e.Player); ok {
+		score, wave = player.Score, player.Wave
+	}
+
+	// When game is over, check and save high score. Headless runs have no
+	// high score table, so nothing is written to disk.
+	highScores, ok := ecs.LookupResource[*highscore.HighScores](s.world)
+	if !ok {
+		return
+	}
+	if highScores.IsHighScore(score) {
+		highScores.AddScore(score, wave)
+	}
 }
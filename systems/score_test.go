@@ -0,0 +1,85 @@
+package systems_test
+
+import (
+	"testing"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+	"github.com/bobbyhiddn/ecs-asteroids/systems"
+)
+
+func TestExtraLives(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      game.ExtraLifeRule
+		score     int
+		lives     int
+		award     int
+		wantLives int
+		wantScore []int // Score reported by each ExtraLife event
+	}{
+		{"below threshold", game.ExtraLifeRule{Every: 10000}, 0, 3, 9999, 3, nil},
+		{"one threshold", game.ExtraLifeRule{Every: 10000}, 9000, 3, 1000, 4, []int{10000}},
+		{"several thresholds", game.ExtraLifeRule{Every: 10000}, 9000, 3, 21000, 6, []int{30000, 30000, 30000}},
+		{"first then every", game.ExtraLifeRule{First: 5000, Every: 15000}, 0, 3, 20000, 5, []int{20000, 20000}},
+		{"capped", game.ExtraLifeRule{Every: 10000, MaxLives: 5}, 0, 4, 50000, 5, []int{50000}},
+		{"already at cap", game.ExtraLifeRule{Every: 10000, MaxLives: 5}, 9000, 5, 1000, 5, nil},
+		{"no rule", game.ExtraLifeRule{}, 0, 3, 100000, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			world := game.NewWorld(1)
+			game.ConfigOf(world).ExtraLives = tt.rule
+			systems.NewScoreSystem(world)
+
+			id := world.CreateEntity()
+			ecs.Set(world, id, components.Player{Score: tt.score, Lives: tt.lives})
+
+			var got []int
+			ecs.Subscribe(world, func(e events.ExtraLife) {
+				if e.Player != id {
+					t.Errorf("extra life went to %v, want %v", e.Player, id)
+				}
+				got = append(got, e.Score)
+			})
+
+			ecs.Publish(world, events.SaucerDestroyed{Points: tt.award, By: id})
+			world.Flush()
+
+			player, _ := ecs.Get[components.Player](world, id)
+			if player.Score != tt.score+tt.award {
+				t.Errorf("score is %d, want %d", player.Score, tt.score+tt.award)
+			}
+			if player.Lives != tt.wantLives {
+				t.Errorf("lives = %d, want %d", player.Lives, tt.wantLives)
+			}
+			if len(got) != len(tt.wantScore) {
+				t.Fatalf("ExtraLife events at scores %v, want %v", got, tt.wantScore)
+			}
+			for i := range got {
+				if got[i] != tt.wantScore[i] {
+					t.Errorf("ExtraLife events at scores %v, want %v", got, tt.wantScore)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRulesets(t *testing.T) {
+	for name, ruleset := range game.Rulesets {
+		if _, ok := game.FlightPresets[ruleset.Flight]; !ok {
+			t.Errorf("ruleset %q uses unknown flight model %q", name, ruleset.Flight)
+		}
+		config := game.DefaultConfig()
+		if !config.UseRuleset(name) || config.ExtraLives != ruleset.ExtraLives || config.Flight != ruleset.Flight {
+			t.Errorf("UseRuleset(%q) did not apply the ruleset", name)
+		}
+	}
+	if game.DefaultConfig().UseRuleset("no such ruleset") {
+		t.Error("UseRuleset accepted an unknown ruleset")
+	}
+}
@@ -0,0 +1,87 @@
+package systems
+
+import (
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+)
+
+// destroyShip costs the ship's player a life. The ship respawns in the
+// middle of the screen, invulnerable for a while, or is disabled when that
+// was the last life. Invulnerable ships are left alone. It reports whether
+// the ship was destroyed.
+func destroyShip(world *ecs.World, shipID ecs.EntityID) bool {
+	players := ecs.Register[components.Player](world)
+	invulnerables := ecs.Register[components.Invulnerable](world)
+
+	// Check if ship is invulnerable
+	if invulnerables.Has(shipID) {
+		return false // Skip collision if ship is invulnerable
+	}
+
+	player, ok := players.Get(shipID)
+	if !ok || player.IsGameOver {
+		return false
+	}
+
+	// Get current position for explosion and respawn
+	pos, _ := ecs.Get[components.Position](world, shipID)
+
+	// Reduce lives
+	player.Lives--
+	if player.Lives < 0 {
+		player.Lives = 0 // Ensure it doesn't go negative
+	}
+
+	ecs.Publish(world, events.ShipHit{
+		Ship:      shipID,
+		X:         pos.X,
+		Y:         pos.Y,
+		LivesLeft: player.Lives,
+	})
+
+	// Check for game over
+	if player.Lives <= 0 {
+		player.IsGameOver = true
+
+		// Update player component
+		players.Set(shipID, player)
+
+		ecs.Publish(world, events.GameOver{
+			Player: shipID,
+			Score:  player.Score,
+		})
+
+		// Remove all components except Player and Input to effectively disable the ship
+		// but keep the player state for restart
+		world.Commands().RemoveComponents(shipID, players, ecs.Register[components.Input](world))
+		return true
+	}
+
+	// Update player component
+	players.Set(shipID, player)
+
+	// Reset velocity and rotation to the ship prefab's values
+	vel, _ := game.PrefabComponent[components.Velocity]("ship")
+	ecs.Set(world, shipID, vel)
+	rot, _ := game.PrefabComponent[components.Rotation]("ship")
+	ecs.Set(world, shipID, rot)
+
+	// Move ship back to center and make invulnerable
+	screen := game.ScreenOf(world)
+	pos.X = screen.CenterX()
+	pos.Y = screen.CenterY()
+	ecs.Set(world, shipID, pos)
+
+	// Add invulnerability
+	invulnerable, _ := game.PrefabComponent[components.Invulnerable]("ship")
+	invulnerables.Set(shipID, invulnerable)
+	return true
+}
+
+// shielded reports whether the ship has a shield up.
+func shielded(world *ecs.World, shipID ecs.EntityID) bool {
+	effects, ok := ecs.Get[components.Effects](world, shipID)
+	return ok && effects.Has(components.PowerUpShield)
+}
@@ -0,0 +1,36 @@
+package systems
+
+import (
+	"fmt"
+
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+)
+
+// AddGameSystems schedules the input and simulation systems, reading the
+// player's controls from source. None of them draw or touch the display, so
+// a frontend adds its own render system and headless runs add nothing.
+func AddGameSystems(world *ecs.World, source InputSource) {
+	world.AddSystem("input", ecs.PhaseInput, NewInputSystem(world, source))
+	world.AddSystem("player", ecs.PhaseSimulation, NewPlayerSystem(world))
+	world.AddSystem("weapon", ecs.PhaseSimulation, NewWeaponSystem(world), ecs.After("player"))
+	world.AddSystem("movement", ecs.PhaseSimulation, NewMovementSystem(world), ecs.After("player", "weapon"))
+	world.AddSystem("invulnerable", ecs.PhaseSimulation, NewInvulnerableSystem(world), ecs.After("movement"))
+	world.AddSystem("collision", ecs.PhaseSimulation, NewCollisionSystem(world), ecs.After("movement", "invulnerable"))
+	world.AddSystem("wave", ecs.PhaseSimulation, NewWaveSystem(world), ecs.After("collision"))
+	world.AddSystem("saucer", ecs.PhaseSimulation, NewSaucerSystem(world), ecs.After("collision"))
+	world.AddSystem("powerup", ecs.PhaseSimulation, NewPowerUpSystem(world), ecs.After("collision"))
+	world.AddSystem("explosion", ecs.PhaseSimulation, NewExplosionSystem(world))
+	world.AddSystem("lifetime", ecs.PhaseSimulation, NewLifetimeSystem(world), ecs.After("collision"))
+	world.AddSystem("score", ecs.PhasePostSimulation, NewScoreSystem(world))
+
+	// Freeze the simulation while the game is over; only input keeps running
+	// so the player can restart
+	ecs.Subscribe(world, func(e events.GameOver) {
+		fmt.Printf("Game is over, waiting for restart input...\n")
+		world.SetPhaseEnabled(ecs.PhaseSimulation, false)
+	})
+	ecs.Subscribe(world, func(e events.GameRestarted) {
+		world.SetPhaseEnabled(ecs.PhaseSimulation, true)
+	})
+}
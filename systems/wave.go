@@ -0,0 +1,160 @@
+package systems
+
+import (
+	"math"
+
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+)
+
+// Attempts at finding a spawn point clear of every ship before settling for
+// the last one tried
+const waveSpawnAttempts = 20
+
+// WaveSystem sends asteroids in waves. Once the field is clear the next,
+// harder wave arrives after a short delay.
+type WaveSystem struct {
+	world      *ecs.World
+	screen     *game.Screen
+	wave       int
+	nextWaveAt float64 // When the next wave arrives, 0 while a wave is in play
+	asteroids  *ecs.Store[components.Asteroid]
+	positions  *ecs.Store[components.Position]
+	velocities *ecs.Store[components.Velocity]
+	players    *ecs.Store[components.Player]
+}
+
+func NewWaveSystem(world *ecs.World) *WaveSystem {
+	s := &WaveSystem{
+		world:      world,
+		screen:     game.ScreenOf(world),
+		asteroids:  ecs.Register[components.Asteroid](world),
+		positions:  ecs.Register[components.Position](world),
+		velocities: ecs.Register[components.Velocity](world),
+		players:    ecs.Register[components.Player](world),
+	}
+
+	// A new game starts over from the first wave on an empty field
+	ecs.Subscribe(world, func(e events.GameRestarted) {
+		s.wave = 0
+		s.nextWaveAt = 0
+		s.asteroids.Each(func(id ecs.EntityID, _ components.Asteroid) {
+			world.Commands().Destroy(id)
+		})
+	})
+	return s
+}
+
+type waveState struct {
+	Wave       int     `json:"wave"`
+	NextWaveAt float64 `json:"next_wave_at"`
+}
+
+// SnapshotState implements ecs.Snapshotter.
+func (s *WaveSystem) SnapshotState() any {
+	return waveState{Wave: s.wave, NextWaveAt: s.nextWaveAt}
+}
+
+// RestoreState implements ecs.Snapshotter.
+func (s *WaveSystem) RestoreState(decode func(v any) error) error {
+	var state waveState
+	if err := decode(&state); err != nil {
+		return err
+	}
+	s.wave = state.Wave
+	s.nextWaveAt = state.NextWaveAt
+	return nil
+}
+
+func (s *WaveSystem) Update(dt float64) {
+	now := s.world.Clock().Now()
+
+	if s.nextWaveAt > 0 {
+		if now >= s.nextWaveAt {
+			s.nextWaveAt = 0
+			s.startWave(s.wave + 1)
+		}
+		return
+	}
+
+	if s.asteroids.Len() > 0 {
+		return
+	}
+
+	// The very first wave doesn't wait
+	if s.wave == 0 {
+		s.startWave(1)
+		return
+	}
+
+	ecs.Publish(s.world, events.WaveCleared{Wave: s.wave})
+	s.nextWaveAt = now + game.ConfigOf(s.world).Waves.Delay
+}
+
+func (s *WaveSystem) startWave(n int) {
+	wave := game.ConfigOf(s.world).Waves.Wave(n)
+	s.wave = n
+
+	for i := 0; i < wave.Large; i++ {
+		s.spawnAsteroid(2, wave)
+	}
+	for i := 0; i < wave.Medium; i++ {
+		s.spawnAsteroid(1, wave)
+	}
+
+	s.players.Each(func(id ecs.EntityID, player components.Player) {
+		player.Wave = n
+		s.players.Set(id, player)
+	})
+
+	ecs.Publish(s.world, events.WaveStarted{Wave: n, Asteroids: wave.Large + wave.Medium})
+}
+
+func (s *WaveSystem) spawnAsteroid(size int, wave game.Wave) {
+	rng := game.RandOf(s.world)
+	x, y := s.spawnPoint()
+
+	angle := rng.Float64() * 2 * math.Pi
+	speed := wave.MinSpeed + rng.Float64()*(wave.MaxSpeed-wave.MinSpeed)
+
+	s.world.Commands().Spawn(func(w *ecs.World) {
+		asteroid := game.CreateAsteroid(w, size)
+		s.positions.Set(asteroid, components.Position{X: x, Y: y})
+		s.velocities.Set(asteroid, components.Velocity{
+			DX:       math.Cos(angle) * speed,
+			DY:       math.Sin(angle) * speed,
+			MaxSpeed: wave.MaxSpeed,
+		})
+	})
+}
+
+// spawnPoint picks a random spot on the playfield at least the curve's safe
+// distance from every ship, measured across the wrapping edges.
+func (s *WaveSystem) spawnPoint() (float64, float64) {
+	rng := game.RandOf(s.world)
+	safe := game.ConfigOf(s.world).Waves.SafeDistance
+
+	var x, y float64
+	for attempt := 0; attempt < waveSpawnAttempts; attempt++ {
+		x = rng.Float64() * float64(s.screen.Width())
+		y = rng.Float64() * float64(s.screen.Height())
+
+		clear := true
+		s.players.Each(func(id ecs.EntityID, _ components.Player) {
+			pos, ok := s.positions.Get(id)
+			if !ok {
+				return
+			}
+			dx, dy := s.screen.WrapDelta(x-pos.X, y-pos.Y)
+			if math.Hypot(dx, dy) < safe {
+				clear = false
+			}
+		})
+		if clear {
+			break
+		}
+	}
+	return x, y
+}
@@ -0,0 +1,160 @@
+package systems
+
+import (
+	"github.com/bobbyhiddn/ecs-asteroids/components"
+	"github.com/bobbyhiddn/ecs-asteroids/ecs"
+	"github.com/bobbyhiddn/ecs-asteroids/events"
+	"github.com/bobbyhiddn/ecs-asteroids/game"
+)
+
+// WeaponSystem fires the weapons of player-controlled entities when their
+// trigger is pulled, holding to each weapon's fire rate and its limit on
+// live projectiles. Power-ups the shooter has collected change how it
+// fires.
+type WeaponSystem struct {
+	world      *ecs.World
+	weapons    *ecs.Store[components.Weapon]
+	inputs     *ecs.Store[components.Input]
+	positions  *ecs.Store[components.Position]
+	rotations  *ecs.Store[components.Rotation]
+	velocities *ecs.Store[components.Velocity]
+	bullets    *ecs.Store[components.Bullet]
+	effects    *ecs.Store[components.Effects]
+	armed      *ecs.Query
+	live       map[ecs.EntityID]int
+}
+
+func NewWeaponSystem(world *ecs.World) *WeaponSystem {
+	s := &WeaponSystem{
+		world:      world,
+		weapons:    ecs.Register[components.Weapon](world),
+		inputs:     ecs.Register[components.Input](world),
+		positions:  ecs.Register[components.Position](world),
+		rotations:  ecs.Register[components.Rotation](world),
+		velocities: ecs.Register[components.Velocity](world),
+		bullets:    ecs.Register[components.Bullet](world),
+		effects:    ecs.Register[components.Effects](world),
+		live:       make(map[ecs.EntityID]int),
+	}
+	s.armed = ecs.NewQuery(s.weapons, s.inputs)
+	return s
+}
+
+func (s *WeaponSystem) Update(dt float64) {
+	now := s.world.Clock().Now()
+
+	// Count every shooter's projectiles still in flight
+	clear(s.live)
+	s.bullets.Each(func(id ecs.EntityID, bullet components.Bullet) {
+		s.live[bullet.ShooterID]++
+	})
+
+	s.armed.Each(func(id ecs.EntityID) {
+		weapon, _ := s.weapons.Get(id)
+		input, _ := s.inputs.Get(id)
+		effects, _ := s.effects.Get(id)
+		weapon = s.powerUp(weapon, effects)
+
+		// A press is used up whether or not the weapon is ready, so it
+		// doesn't fire late
+		pulled := input.Shoot || (weapon.AutoFire && input.ShootHeld)
+		if input.Shoot {
+			input.Shoot = false
+			s.inputs.Set(id, input)
+		}
+		if !pulled || now < weapon.NextShot {
+			return
+		}
+
+		// The cap counts projectiles, not volleys, so a spread fires less
+		// often. A volley bigger than the cap still fires once the last
+		// one has gone.
+		angles := max(len(weapon.Spread), 1)
+		if weapon.MaxLive > 0 && s.live[id] > 0 && s.live[id]+angles > weapon.MaxLive {
+			return
+		}
+
+		if s.fire(id, weapon, effects.Has(components.PowerUpPiercing)) {
+			stored, _ := s.weapons.Get(id)
+			stored.NextShot = now + weapon.Interval
+			s.weapons.Set(id, stored)
+		}
+	})
+}
+
+// powerUp returns the weapon as the shooter's power-ups change it. Rapid
+// fire repeats quickly with no limit on live shots and triple shot fans
+// three projectiles out.
+func (s *WeaponSystem) powerUp(weapon components.Weapon, effects components.Effects) components.Weapon {
+	table := game.ConfigOf(s.world).PowerUps
+	if effects.Has(components.PowerUpRapidFire) {
+		weapon.AutoFire = true
+		weapon.Interval = min(weapon.Interval, table.RapidFireInterval)
+		weapon.MaxLive = 0
+	}
+	if effects.Has(components.PowerUpTripleShot) && len(weapon.Spread) < 3 {
+		weapon.Spread = []float64{-table.TripleShotSpread, 0, table.TripleShotSpread}
+	}
+	return weapon
+}
+
+// fire shoots one volley from the shooter's position along its rotation.
+func (s *WeaponSystem) fire(id ecs.EntityID, weapon components.Weapon, piercing bool) bool {
+	pos, ok := s.positions.Get(id)
+	if !ok {
+		return false
+	}
+	rot, _ := s.rotations.Get(id)
+
+	var inherited components.Velocity
+	if weapon.InheritVelocity {
+		inherited, _ = s.velocities.Get(id)
+	}
+
+	spread := weapon.Spread
+	if len(spread) == 0 {
+		spread = []float64{0}
+	}
+	for _, offset := range spread {
+		angle := rot.Angle + offset
+		s.world.Commands().Spawn(func(w *ecs.World) {
+			bullet := game.CreateBulletFromPrefab(w, weapon.Prefab, pos.X, pos.Y, angle, id)
+
+			vel, _ := ecs.Get[components.Velocity](w, bullet)
+			vel.DX += inherited.DX
+			vel.DY += inherited.DY
+			ecs.Set(w, bullet, vel)
+
+			if piercing {
+				ecs.Set(w, bullet, components.Bullet{ShooterID: id, Piercing: true})
+			}
+		})
+		ecs.Publish(s.world, events.BulletFired{
+			Shooter: id,
+			X:       pos.X,
+			Y:       pos.Y,
+			Angle:   angle,
+		})
+	}
+	s.live[id] += len(spread)
+	return true
+}
+
+// shooterOf returns the player who fired a bullet, or ecs.NoEntity when it
+// wasn't a player or they have since been destroyed.
+func shooterOf(world *ecs.World, bulletID ecs.EntityID) ecs.EntityID {
+	if bullet, ok := ecs.Get[components.Bullet](world, bulletID); ok {
+		if world.IsAlive(bullet.ShooterID) && ecs.Has[components.Player](world, bullet.ShooterID) {
+			return bullet.ShooterID
+		}
+	}
+	return ecs.NoEntity
+}
+
+// spendBullet destroys a bullet that has hit something, unless it pierces.
+func spendBullet(world *ecs.World, bulletID ecs.EntityID) {
+	if bullet, ok := ecs.Get[components.Bullet](world, bulletID); ok && bullet.Piercing {
+		return
+	}
+	world.Commands().Destroy(bulletID)
+}